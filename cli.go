@@ -0,0 +1,82 @@
+package plate
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+// Main runs the plate command line for the schema and exits with its status
+// It is meant to be called from a generate program:
+//
+//	go run generate.go                   # generate query builders into opts.OutputDir
+//	go run generate.go check schema.sql  # report drift between the models and DDL files
+//	go run generate.go diff schema.sql   # print the migration from DDL files to the models
+func Main(schema Schema, opts GenerateOptions) {
+	os.Exit(Run(schema, opts, os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// Run runs a plate subcommand and returns its exit status
+// The status is 1 when check finds drift or diff has changes to review, and 2 on usage or other errors
+func Run(schema Schema, opts GenerateOptions, args []string, stdout, stderr io.Writer) int {
+	cmd := "generate"
+	if len(args) > 0 {
+		cmd, args = args[0], args[1:]
+	}
+
+	switch cmd {
+	case "generate":
+		if len(args) > 0 {
+			fmt.Fprintln(stderr, "usage: generate")
+			return 2
+		}
+		if err := NewGenerator().Generate(schema, opts); err != nil {
+			fmt.Fprintf(stderr, "plate: %v\n", err)
+			return 2
+		}
+		fmt.Fprintln(stdout, "Code generation completed successfully!")
+		return 0
+
+	case "check":
+		if len(args) == 0 {
+			fmt.Fprintln(stderr, "usage: check <ddl files...>")
+			return 2
+		}
+		drifts, err := CheckSchemaFiles(schema, args...)
+		if err != nil {
+			fmt.Fprintf(stderr, "plate: %v\n", err)
+			return 2
+		}
+		for _, drift := range drifts {
+			fmt.Fprintln(stdout, drift)
+		}
+		if len(drifts) > 0 {
+			fmt.Fprintf(stderr, "plate: %d differences between the models and the DDL\n", len(drifts))
+			return 1
+		}
+		return 0
+
+	case "diff":
+		if len(args) == 0 {
+			fmt.Fprintln(stderr, "usage: diff <ddl files...>")
+			return 2
+		}
+		migration, err := DiffSchemaFiles(schema, args...)
+		if err != nil {
+			fmt.Fprintf(stderr, "plate: %v\n", err)
+			return 2
+		}
+		fmt.Fprint(stdout, migration.SQL())
+		for _, drift := range migration.Review {
+			fmt.Fprintf(stderr, "review: %s\n", drift)
+		}
+		if len(migration.Review) > 0 {
+			fmt.Fprintf(stderr, "plate: %d changes need manual review\n", len(migration.Review))
+			return 1
+		}
+		return 0
+	}
+
+	fmt.Fprintf(stderr, "plate: unknown command %q, expected generate, check or diff\n", cmd)
+	return 2
+}
@@ -0,0 +1,67 @@
+// Command plate generates query builders, checks drift and diffs DDL from a plate.json config
+//
+//	plate [-config plate.json] generate
+//	plate [-config plate.json] check [ddl files...]
+//	plate [-config plate.json] diff [ddl files...]
+//
+// check and diff use the ddl files of the config when none are given. Use it from go:generate:
+//
+//	//go:generate go run github.com/rail44/plate/cmd/plate generate
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/rail44/plate"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+// run executes the command and returns its exit status, following plate.Run
+func run(args []string) int {
+	flags := flag.NewFlagSet("plate", flag.ContinueOnError)
+	configPath := flags.String("config", "plate.json", "path to the config file")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: plate [-config plate.json] generate | check [ddl files...] | diff [ddl files...]")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+
+	cfg, err := plate.LoadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "plate: %v\n", err)
+		return 2
+	}
+
+	// Paths in the config are relative to its directory
+	dir := filepath.Dir(*configPath)
+	schema, err := cfg.Schema(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "plate: %v\n", err)
+		return 2
+	}
+	opts := plate.GenerateOptions{
+		OutputDir: filepath.Join(dir, cfg.Output),
+		Clean:     cfg.Clean,
+	}
+
+	return plate.Run(schema, opts, commandArgs(cfg, dir, flags.Args()), os.Stdout, os.Stderr)
+}
+
+// commandArgs fills in the ddl files of the config for check and diff without files
+func commandArgs(cfg *plate.Config, dir string, args []string) []string {
+	if len(args) != 1 || (args[0] != "check" && args[0] != "diff") {
+		return args
+	}
+	for _, file := range cfg.DDL {
+		args = append(args, filepath.Join(dir, file))
+	}
+	return args
+}
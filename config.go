@@ -0,0 +1,138 @@
+package plate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// Config is the declarative form of a Schema read by the plate command from plate.json
+//
+//	{
+//	  "models": "example.com/app/models",
+//	  "output": "./generated",
+//	  "clean": true,
+//	  "ddl": ["schema.sql"],
+//	  "tables": [
+//	    {"table": "user", "model": "User"},
+//	    {"table": "post", "model": "Post", "relations": [
+//	      {"name": "Author", "target": "User", "from": "UserID", "to": "ID", "reverse": "Posts"}
+//	    ]}
+//	  ]
+//	}
+//
+// Paths are relative to the directory of the config file
+type Config struct {
+	Models    string       `json:"models"`    // Package that defines the models, an import path or a relative path
+	Output    string       `json:"output"`    // Output directory of generated code
+	Clean     bool         `json:"clean"`     // Remove the output directory before generating
+	DDL       []string     `json:"ddl"`       // DDL files compared by check and diff when none are given
+	Tables    []TableEntry `json:"tables"`    // Tables, see TableConfig
+	Junctions []TableEntry `json:"junctions"` // Junction tables, see JunctionConfig
+}
+
+// TableEntry declares a table and the model type it maps to
+type TableEntry struct {
+	Table     string          `json:"table"` // Database table name
+	Model     string          `json:"model"` // Model type name in the models package
+	Relations []RelationEntry `json:"relations"`
+}
+
+// RelationEntry declares a relation, see Relation
+type RelationEntry struct {
+	Name    string `json:"name"`
+	Target  string `json:"target"`
+	From    string `json:"from"`
+	To      string `json:"to"`
+	Reverse string `json:"reverse"` // ReverseName of the relation
+}
+
+// LoadConfig reads and validates a config file
+func LoadConfig(path string) (*Config, error) {
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(src))
+	dec.DisallowUnknownFields()
+	cfg := &Config{}
+	if err := dec.Decode(cfg); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if cfg.Output == "" {
+		cfg.Output = "./generated"
+	}
+	return cfg, nil
+}
+
+// validate checks the fields needed to build the Schema
+func (c *Config) validate() error {
+	if c.Models == "" {
+		return fmt.Errorf("models is required")
+	}
+	if len(c.Tables) == 0 {
+		return fmt.Errorf("no tables")
+	}
+	for _, entries := range [][]TableEntry{c.Tables, c.Junctions} {
+		for i, t := range entries {
+			if t.Table == "" || t.Model == "" {
+				return fmt.Errorf("table %d: table and model are required", i)
+			}
+		}
+	}
+	return nil
+}
+
+// Schema loads the models package from source in dir and builds the Schema of the config
+func (c *Config) Schema(dir string) (Schema, error) {
+	models, err := LoadModels(dir, c.Models)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	tableSchema := func(t TableEntry) (TableSchema, error) {
+		ts, ok := models[t.Model]
+		if !ok {
+			return TableSchema{}, fmt.Errorf("table %s: model %s is not a struct with spanner tags in %s", t.Table, t.Model, c.Models)
+		}
+		ts.TableName = t.Table
+		return ts, nil
+	}
+
+	var schema Schema
+	for _, t := range c.Tables {
+		ts, err := tableSchema(t)
+		if err != nil {
+			return Schema{}, err
+		}
+		schema.Tables = append(schema.Tables, TableConfig{Schema: ts, Relations: t.relations()})
+	}
+	for _, t := range c.Junctions {
+		ts, err := tableSchema(t)
+		if err != nil {
+			return Schema{}, err
+		}
+		schema.Junctions = append(schema.Junctions, JunctionConfig{Schema: ts, Relations: t.relations()})
+	}
+	return schema, nil
+}
+
+// relations converts the relation entries of a table
+func (t TableEntry) relations() []Relation {
+	var relations []Relation
+	for _, r := range t.Relations {
+		relations = append(relations, Relation{
+			Name:        r.Name,
+			Target:      r.Target,
+			From:        r.From,
+			To:          r.To,
+			ReverseName: r.Reverse,
+		})
+	}
+	return relations
+}
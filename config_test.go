@@ -0,0 +1,171 @@
+package plate_test
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rail44/plate"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		wantErr string
+	}{
+		{
+			name: "valid config",
+			config: `{
+  "models": "example.com/app/models",
+  "tables": [
+    {"table": "user", "model": "User"},
+    {"table": "post", "model": "Post", "relations": [
+      {"name": "Author", "target": "User", "from": "UserID", "to": "ID", "reverse": "Posts"}
+    ]}
+  ]
+}`,
+		},
+		{
+			name:    "missing models",
+			config:  `{"tables": [{"table": "user", "model": "User"}]}`,
+			wantErr: "models is required",
+		},
+		{
+			name:    "table without model",
+			config:  `{"models": "example.com/app/models", "tables": [{"table": "user"}]}`,
+			wantErr: "table 0: table and model are required",
+		},
+		{
+			name:    "unknown field",
+			config:  `{"models": "example.com/app/models", "tabels": []}`,
+			wantErr: `unknown field "tabels"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "plate.json")
+			if err := os.WriteFile(path, []byte(tt.config), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			cfg, err := plate.LoadConfig(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Error mismatch\ngot:  %v\nwant: %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if cfg.Output != "./generated" {
+				t.Errorf("Output = %q, want the default ./generated", cfg.Output)
+			}
+		})
+	}
+}
+
+func TestLoadModels(t *testing.T) {
+	models, err := plate.LoadModels(".", "./testdata/models")
+	if err != nil {
+		t.Fatalf("Failed to load models: %v", err)
+	}
+	if _, ok := models["Note"]; ok {
+		t.Errorf("Note has no spanner tags and should not be a model")
+	}
+
+	account, ok := models["Account"]
+	if !ok {
+		t.Fatalf("Account not loaded, got %v", models)
+	}
+
+	// Positions point at the declarations in the source
+	if !strings.HasSuffix(account.Pos, filepath.Join("testdata", "models", "models.go")+":13:6") {
+		t.Errorf("Account.Pos = %q, want the position of the type name", account.Pos)
+	}
+	if !strings.HasSuffix(account.Columns[0].Pos, "models.go:15:2") {
+		t.Errorf("ID.Pos = %q, want the position of the field", account.Columns[0].Pos)
+	}
+	account.Pos = ""
+	for i := range account.Columns {
+		account.Columns[i].Pos = ""
+	}
+	want := plate.TableSchema{
+		TypeName: "Account",
+		Columns: []plate.ColumnSchema{
+			{Name: "ID", ColumnName: "id", SpannerType: "STRING(36)", GoType: "string", NotNull: true, Doc: "ID is the primary key"},
+			{Name: "Email", ColumnName: "email", SpannerType: "STRING", GoType: "string", NotNull: true, Doc: "Email is the address used to sign in\nIt is unique across accounts"},
+			{Name: "Status", ColumnName: "status", SpannerType: "STRING", GoType: "models.Status", Imports: []string{"github.com/rail44/plate/testdata/models"}, NotNull: true, Doc: "Lifecycle state of the account"},
+			{Name: "Previous", ColumnName: "previous_status", SpannerType: "STRING(16)", GoType: "models.NullStatus", Imports: []string{"github.com/rail44/plate/testdata/models"}},
+			{Name: "ManagerID", ColumnName: "manager_id", SpannerType: "STRING(36)", GoType: "*string", Relation: "belongs_to=Account,reverse=Reports"},
+			{Name: "Avatar", ColumnName: "avatar", SpannerType: "BYTES", GoType: "[]byte"},
+			{Name: "Scores", ColumnName: "scores", SpannerType: "ARRAY<INT64>", GoType: "[]int64"},
+			{Name: "CreatedAt", ColumnName: "created_at", SpannerType: "TIMESTAMP", GoType: "time.Time", Imports: []string{"time"}, NotNull: true},
+		},
+	}
+	if !reflect.DeepEqual(account, want) {
+		t.Errorf("Account mismatch\ngot:  %+v\nwant: %+v", account, want)
+	}
+	if !models["ArticleLabel"].Junction {
+		t.Errorf("ArticleLabel carries the junction marker")
+	}
+}
+
+func TestConfigSchema(t *testing.T) {
+	cfg := &plate.Config{
+		Models: "./testdata/models",
+		Tables: []plate.TableEntry{
+			{Table: "account", Model: "Account", Relations: []plate.RelationEntry{
+				{Name: "Owner", Target: "Account", From: "ID", To: "ManagerID"},
+			}},
+		},
+	}
+
+	schema, err := cfg.Schema(".")
+	if err != nil {
+		t.Fatalf("Failed to build schema: %v", err)
+	}
+	if len(schema.Tables) != 1 || schema.Tables[0].Schema.TableName != "account" {
+		t.Fatalf("Unexpected tables: %+v", schema.Tables)
+	}
+	wantRelations := []plate.Relation{{Name: "Owner", Target: "Account", From: "ID", To: "ManagerID"}}
+	if !reflect.DeepEqual(schema.Tables[0].Relations, wantRelations) {
+		t.Errorf("Relations mismatch\ngot:  %+v\nwant: %+v", schema.Tables[0].Relations, wantRelations)
+	}
+
+	// Field doc comments become accessor docs
+	outputDir := filepath.Join("testdata", "models", "generated")
+	files, err := plate.NewGenerator().GenerateFiles(schema, outputDir)
+	if err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+	code := files.Files["account/account.go"]
+	for _, want := range []string{
+		"// Email is the address used to sign in\n// It is unique across accounts\nfunc Email() types.StringColumn[tables.Account, string] {",
+		"// Lifecycle state of the account\nfunc Status() types.StringColumn[tables.Account, models.Status] {",
+	} {
+		if !strings.Contains(code, want) {
+			t.Errorf("Generated code does not contain %q\n%s", want, code)
+		}
+	}
+
+	// Named field types such as models.Status are imported, so the generated code compiles
+	t.Cleanup(func() { os.RemoveAll(outputDir) })
+	if err := files.WriteToDirectory(outputDir); err != nil {
+		t.Fatalf("Failed to write generated files: %v", err)
+	}
+	vetCmd := exec.Command("go", "vet", "./"+filepath.ToSlash(outputDir)+"/...")
+	if output, err := vetCmd.CombinedOutput(); err != nil {
+		t.Errorf("Generated code does not pass go vet: %v\n%s", err, output)
+	}
+
+	cfg.Tables[0].Model = "Note"
+	if _, err := cfg.Schema("."); err == nil || !strings.Contains(err.Error(), "model Note is not a struct with spanner tags") {
+		t.Errorf("Expected an error for a model without spanner tags, got %v", err)
+	}
+}
@@ -0,0 +1,534 @@
+package plate
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish"
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/cloudspannerecosystem/memefish/token"
+)
+
+// SchemaFromDDL builds a Schema from Spanner DDL statements
+// CREATE TABLE, CREATE INDEX, ALTER TABLE and DROP statements are applied in order
+// Relations are inferred from single-column foreign keys and INTERLEAVE IN PARENT, and
+// a table whose primary key consists of two foreign keys to different tables becomes a junction table
+func SchemaFromDDL(r io.Reader) (Schema, error) {
+	l, err := loadDDL(r)
+	if err != nil {
+		return Schema{}, err
+	}
+	return l.schema(), nil
+}
+
+// SchemaFromDDLFiles builds a Schema from Spanner DDL files applied in the given order,
+// such as the files of a migrations directory
+func SchemaFromDDLFiles(paths ...string) (Schema, error) {
+	l, err := loadDDLFiles(paths)
+	if err != nil {
+		return Schema{}, err
+	}
+	return l.schema(), nil
+}
+
+// loadDDL loads the tables defined by DDL statements read from r
+func loadDDL(r io.Reader) (*ddlLoader, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read DDL: %w", err)
+	}
+
+	l := &ddlLoader{}
+	if err := l.load("<input>", string(src)); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// loadDDLFiles loads the tables defined by DDL files applied in the given order
+func loadDDLFiles(paths []string) (*ddlLoader, error) {
+	l := &ddlLoader{}
+	for _, path := range paths {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read DDL: %w", err)
+		}
+		if err := l.load(path, string(src)); err != nil {
+			return nil, err
+		}
+	}
+	return l, nil
+}
+
+// ddlLoader accumulates the tables defined by DDL statements
+type ddlLoader struct {
+	tables []*ddlTable // In creation order
+}
+
+// ddlTable is a table being built from DDL statements
+type ddlTable struct {
+	schema      TableSchema
+	foreignKeys []ddlForeignKey
+}
+
+// ddlForeignKey is a FOREIGN KEY constraint of a table
+type ddlForeignKey struct {
+	name       string // Empty for unnamed constraints
+	columns    []string
+	refTable   string
+	refColumns []string
+}
+
+// spannerGoTypes maps Spanner scalar types to the Go types used for their values
+var spannerGoTypes = map[ast.ScalarTypeName]string{
+	ast.BoolTypeName:      "bool",
+	ast.Int64TypeName:     "int64",
+	ast.Float32TypeName:   "float32",
+	ast.Float64TypeName:   "float64",
+	ast.StringTypeName:    "string",
+	ast.BytesTypeName:     "[]byte",
+	ast.DateTypeName:      "civil.Date",
+	ast.TimestampTypeName: "time.Time",
+	ast.NumericTypeName:   "big.Rat",
+	ast.JSONTypeName:      "spanner.NullJSON",
+}
+
+// load parses the DDL statements of a file and applies them
+func (l *ddlLoader) load(path, src string) error {
+	ddls, err := memefish.ParseDDLs(path, src)
+	if err != nil {
+		return fmt.Errorf("failed to parse DDL: %w", err)
+	}
+
+	file := &token.File{FilePath: path, Buffer: src}
+	for _, ddl := range ddls {
+		if err := l.apply(ddl); err != nil {
+			return fmt.Errorf("%s: %w", file.Position(ddl.Pos(), ddl.End()), err)
+		}
+	}
+	return nil
+}
+
+// apply applies a DDL statement to the tables
+// Statements that do not change tables, columns or indexes are ignored
+func (l *ddlLoader) apply(ddl ast.DDL) error {
+	switch ddl := ddl.(type) {
+	case *ast.CreateTable:
+		return l.createTable(ddl)
+	case *ast.CreateIndex:
+		return l.createIndex(ddl)
+	case *ast.AlterTable:
+		return l.alterTable(ddl)
+	case *ast.DropTable:
+		name := pathName(ddl.Name)
+		if l.table(name) == nil {
+			if ddl.IfExists {
+				return nil
+			}
+			return fmt.Errorf("table %s does not exist", name)
+		}
+		l.dropTable(name)
+	case *ast.DropIndex:
+		name := pathName(ddl.Name)
+		if !l.dropIndex(name) && !ddl.IfExists {
+			return fmt.Errorf("index %s does not exist", name)
+		}
+	}
+	return nil
+}
+
+// createTable adds the table defined by a CREATE TABLE statement
+func (l *ddlLoader) createTable(ct *ast.CreateTable) error {
+	name := pathName(ct.Name)
+	if l.table(name) != nil {
+		if ct.IfNotExists {
+			return nil
+		}
+		return fmt.Errorf("table %s already exists", name)
+	}
+
+	t := &ddlTable{
+		schema: TableSchema{
+			TableName: name,
+			TypeName:  toCamelCase(strings.ReplaceAll(name, ".", "_")),
+		},
+	}
+	for _, def := range ct.Columns {
+		if err := t.addColumn(def); err != nil {
+			return err
+		}
+		if def.PrimaryKey {
+			t.schema.PrimaryKey = append(t.schema.PrimaryKey, IndexKey{Column: def.Name.Name})
+		}
+	}
+	t.schema.PrimaryKey = append(t.schema.PrimaryKey, indexKeys(ct.PrimaryKeys)...)
+
+	if ct.Cluster != nil {
+		parent := l.table(pathName(ct.Cluster.TableName))
+		if parent == nil {
+			return fmt.Errorf("parent table %s does not exist", pathName(ct.Cluster.TableName))
+		}
+		t.schema.Parent = parent.schema.TableName
+		t.schema.OnDeleteCascade = ct.Cluster.OnDelete == ast.OnDeleteCascade
+	}
+
+	// Register the table first so foreign keys may reference the table itself
+	l.tables = append(l.tables, t)
+	for _, tc := range ct.TableConstraints {
+		if err := l.addConstraint(t, tc); err != nil {
+			l.tables = l.tables[:len(l.tables)-1]
+			return err
+		}
+	}
+	return nil
+}
+
+// createIndex adds the index defined by a CREATE INDEX statement to its table
+func (l *ddlLoader) createIndex(ci *ast.CreateIndex) error {
+	name := pathName(ci.Name)
+	if l.index(name) != nil {
+		if ci.IfNotExists {
+			return nil
+		}
+		return fmt.Errorf("index %s already exists", name)
+	}
+
+	t := l.table(pathName(ci.TableName))
+	if t == nil {
+		return fmt.Errorf("table %s does not exist", pathName(ci.TableName))
+	}
+
+	index := IndexSchema{
+		Name:         name,
+		Keys:         indexKeys(ci.Keys),
+		Unique:       ci.Unique,
+		NullFiltered: ci.NullFiltered,
+	}
+	for _, key := range index.Keys {
+		if t.column(key.Column) == nil {
+			return fmt.Errorf("column %s does not exist in table %s", key.Column, t.schema.TableName)
+		}
+	}
+	if ci.Storing != nil {
+		for _, col := range ci.Storing.Columns {
+			index.Storing = append(index.Storing, col.Name)
+		}
+	}
+
+	t.schema.Indexes = append(t.schema.Indexes, index)
+	return nil
+}
+
+// alterTable applies an ALTER TABLE statement
+func (l *ddlLoader) alterTable(at *ast.AlterTable) error {
+	t := l.table(pathName(at.Name))
+	if t == nil {
+		return fmt.Errorf("table %s does not exist", pathName(at.Name))
+	}
+
+	switch alt := at.TableAlteration.(type) {
+	case *ast.AddColumn:
+		if t.column(alt.Column.Name.Name) != nil && alt.IfNotExists {
+			return nil
+		}
+		return t.addColumn(alt.Column)
+	case *ast.DropColumn:
+		return t.dropColumn(alt.Name.Name)
+	case *ast.AlterColumn:
+		col := t.column(alt.Name.Name)
+		if col == nil {
+			return fmt.Errorf("column %s does not exist in table %s", alt.Name.Name, t.schema.TableName)
+		}
+		if ct, ok := alt.Alteration.(*ast.AlterColumnType); ok {
+			goType, err := goTypeForSchemaType(ct.Type)
+			if err != nil {
+				return fmt.Errorf("column %s: %w", alt.Name.Name, err)
+			}
+			col.SpannerType = ct.Type.SQL()
+			col.GoType = goType
+			col.NotNull = ct.NotNull
+		}
+	case *ast.AddTableConstraint:
+		return l.addConstraint(t, alt.TableConstraint)
+	case *ast.DropConstraint:
+		for i, fk := range t.foreignKeys {
+			if strings.EqualFold(fk.name, alt.Name.Name) {
+				t.foreignKeys = append(t.foreignKeys[:i], t.foreignKeys[i+1:]...)
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// addConstraint records a FOREIGN KEY constraint, ignoring other constraints such as CHECK
+func (l *ddlLoader) addConstraint(t *ddlTable, tc *ast.TableConstraint) error {
+	fk, ok := tc.Constraint.(*ast.ForeignKey)
+	if !ok {
+		return nil
+	}
+
+	ref := l.table(pathName(fk.ReferenceTable))
+	if ref == nil {
+		return fmt.Errorf("referenced table %s does not exist", pathName(fk.ReferenceTable))
+	}
+
+	foreignKey := ddlForeignKey{refTable: ref.schema.TableName}
+	if tc.Name != nil {
+		foreignKey.name = tc.Name.Name
+	}
+	for _, col := range fk.Columns {
+		if t.column(col.Name) == nil {
+			return fmt.Errorf("column %s does not exist in table %s", col.Name, t.schema.TableName)
+		}
+		foreignKey.columns = append(foreignKey.columns, col.Name)
+	}
+	for _, col := range fk.ReferenceColumns {
+		if ref.column(col.Name) == nil {
+			return fmt.Errorf("column %s does not exist in table %s", col.Name, ref.schema.TableName)
+		}
+		foreignKey.refColumns = append(foreignKey.refColumns, col.Name)
+	}
+
+	t.foreignKeys = append(t.foreignKeys, foreignKey)
+	return nil
+}
+
+// table returns the table with the given name, or nil if it does not exist
+// Table names are case-insensitive as in Spanner
+func (l *ddlLoader) table(name string) *ddlTable {
+	for _, t := range l.tables {
+		if strings.EqualFold(t.schema.TableName, name) {
+			return t
+		}
+	}
+	return nil
+}
+
+// dropTable removes the table with the given name
+func (l *ddlLoader) dropTable(name string) {
+	for i, t := range l.tables {
+		if strings.EqualFold(t.schema.TableName, name) {
+			l.tables = append(l.tables[:i], l.tables[i+1:]...)
+			return
+		}
+	}
+}
+
+// index returns the index with the given name, or nil if it does not exist
+func (l *ddlLoader) index(name string) *IndexSchema {
+	for _, t := range l.tables {
+		for i := range t.schema.Indexes {
+			if strings.EqualFold(t.schema.Indexes[i].Name, name) {
+				return &t.schema.Indexes[i]
+			}
+		}
+	}
+	return nil
+}
+
+// dropIndex removes the index with the given name and reports whether it existed
+func (l *ddlLoader) dropIndex(name string) bool {
+	for _, t := range l.tables {
+		for i, index := range t.schema.Indexes {
+			if strings.EqualFold(index.Name, name) {
+				t.schema.Indexes = append(t.schema.Indexes[:i], t.schema.Indexes[i+1:]...)
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// addColumn adds a column definition to the table
+// Hidden columns, such as TOKENLIST columns for full-text search, are skipped
+func (t *ddlTable) addColumn(def *ast.ColumnDef) error {
+	name := def.Name.Name
+	if t.column(name) != nil {
+		return fmt.Errorf("column %s already exists in table %s", name, t.schema.TableName)
+	}
+	if !def.Hidden.Invalid() {
+		return nil
+	}
+
+	goType, err := goTypeForSchemaType(def.Type)
+	if err != nil {
+		return fmt.Errorf("column %s: %w", name, err)
+	}
+
+	t.schema.Columns = append(t.schema.Columns, ColumnSchema{
+		Name:        toCamelCase(name),
+		ColumnName:  name,
+		SpannerType: def.Type.SQL(),
+		GoType:      goType,
+		NotNull:     def.NotNull,
+	})
+	return nil
+}
+
+// dropColumn removes the column with the given name from the table
+func (t *ddlTable) dropColumn(name string) error {
+	for i, col := range t.schema.Columns {
+		if strings.EqualFold(col.ColumnName, name) {
+			t.schema.Columns = append(t.schema.Columns[:i], t.schema.Columns[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("column %s does not exist in table %s", name, t.schema.TableName)
+}
+
+// column returns the column with the given name, or nil if it does not exist
+func (t *ddlTable) column(name string) *ColumnSchema {
+	for i := range t.schema.Columns {
+		if strings.EqualFold(t.schema.Columns[i].ColumnName, name) {
+			return &t.schema.Columns[i]
+		}
+	}
+	return nil
+}
+
+// schema builds the Schema from the loaded tables
+func (l *ddlLoader) schema() Schema {
+	var schema Schema
+	for _, t := range l.tables {
+		relations := l.relations(t)
+		if t.isJunction(relations) {
+			relations[0].ReverseName = pluralize(relations[1].Target)
+			relations[1].ReverseName = pluralize(relations[0].Target)
+			schema.Junctions = append(schema.Junctions, JunctionConfig{
+				Schema:    t.schema,
+				Relations: relations,
+			})
+			continue
+		}
+
+		schema.Tables = append(schema.Tables, TableConfig{
+			Schema:    t.schema,
+			Relations: relations,
+		})
+	}
+	return schema
+}
+
+// relations infers the belongs_to relations of a table from INTERLEAVE IN PARENT
+// and single-column foreign keys
+func (l *ddlLoader) relations(t *ddlTable) []Relation {
+	var relations []Relation
+	related := make(map[string]bool) // Columns that already have a relation
+
+	addRelation := func(column, refTable, refColumn string) {
+		ref := l.table(refTable)
+		if ref == nil || related[strings.ToLower(column)] {
+			return
+		}
+		from := t.column(column)
+		to := ref.column(refColumn)
+		if from == nil || to == nil {
+			return
+		}
+
+		related[strings.ToLower(column)] = true
+		relations = append(relations, Relation{
+			Name:   relationName(from.Name, ref.schema.TypeName),
+			Target: ref.schema.TypeName,
+			From:   from.Name,
+			To:     to.Name,
+		})
+	}
+
+	// An interleaved table's primary key starts with the primary key of its parent
+	if parent := l.table(t.schema.Parent); parent != nil && len(parent.schema.PrimaryKey) == 1 && len(t.schema.PrimaryKey) > 0 {
+		addRelation(t.schema.PrimaryKey[0].Column, parent.schema.TableName, parent.schema.PrimaryKey[0].Column)
+	}
+
+	for _, fk := range t.foreignKeys {
+		if len(fk.columns) == 1 {
+			addRelation(fk.columns[0], fk.refTable, fk.refColumns[0])
+		}
+	}
+
+	// Name the reverse relations after this table, prefixed by the relation name
+	// when several relations point to the same table
+	targets := make(map[string]int)
+	for _, rel := range relations {
+		targets[rel.Target]++
+	}
+	for i, rel := range relations {
+		relations[i].ReverseName = pluralize(t.schema.TypeName)
+		if targets[rel.Target] > 1 {
+			relations[i].ReverseName = rel.Name + relations[i].ReverseName
+		}
+	}
+
+	return relations
+}
+
+// isJunction reports whether the table links two other tables through its primary key
+func (t *ddlTable) isJunction(relations []Relation) bool {
+	if len(relations) != 2 || len(t.schema.PrimaryKey) != 2 || relations[0].Target == relations[1].Target {
+		return false
+	}
+
+	for _, key := range t.schema.PrimaryKey {
+		col := t.column(key.Column)
+		if col == nil || (col.Name != relations[0].From && col.Name != relations[1].From) {
+			return false
+		}
+	}
+	return true
+}
+
+// relationName derives a relation name from its foreign key field (e.g., "AuthorID" -> "Author")
+// The target type name is used when the field does not end with ID
+func relationName(field, target string) string {
+	for _, suffix := range []string{"ID", "Id"} {
+		if name := strings.TrimSuffix(field, suffix); name != field && name != "" {
+			return name
+		}
+	}
+	return target
+}
+
+// goTypeForSchemaType returns the Go type used for values of a Spanner column type
+func goTypeForSchemaType(t ast.SchemaType) (string, error) {
+	switch t := t.(type) {
+	case *ast.ScalarSchemaType:
+		if goType, ok := spannerGoTypes[t.Name]; ok {
+			return goType, nil
+		}
+	case *ast.SizedSchemaType:
+		if goType, ok := spannerGoTypes[t.Name]; ok {
+			return goType, nil
+		}
+	case *ast.ArraySchemaType:
+		item, err := goTypeForSchemaType(t.Item)
+		if err != nil {
+			return "", err
+		}
+		return "[]" + item, nil
+	}
+	return "", fmt.Errorf("unsupported column type %s", t.SQL())
+}
+
+// indexKeys converts primary key or index keys
+func indexKeys(keys []*ast.IndexKey) []IndexKey {
+	var result []IndexKey
+	for _, key := range keys {
+		result = append(result, IndexKey{
+			Column: key.Name.Name,
+			Desc:   key.Dir == ast.DirectionDesc,
+		})
+	}
+	return result
+}
+
+// pathName returns the dotted name of a possibly schema-qualified path
+func pathName(p *ast.Path) string {
+	names := make([]string, 0, len(p.Idents))
+	for _, ident := range p.Idents {
+		names = append(names, ident.Name)
+	}
+	return strings.Join(names, ".")
+}
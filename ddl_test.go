@@ -0,0 +1,842 @@
+package plate_test
+
+import (
+	"go/format"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rail44/plate"
+)
+
+func TestSchemaFromDDL(t *testing.T) {
+	tests := []struct {
+		name    string
+		ddl     string
+		want    plate.Schema
+		wantErr string
+	}{
+		{
+			name: "foreign keys, interleave and junction table",
+			ddl: `
+CREATE TABLE user (
+  id STRING(36) NOT NULL,
+  name STRING(MAX),
+  manager_id STRING(36),
+  CONSTRAINT FK_UserManager FOREIGN KEY (manager_id) REFERENCES user (id),
+) PRIMARY KEY (id);
+
+CREATE TABLE post (
+  id STRING(36) NOT NULL,
+  user_id STRING(36) NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  FOREIGN KEY (user_id) REFERENCES user (id),
+) PRIMARY KEY (id);
+
+CREATE TABLE tag (
+  id STRING(36) NOT NULL,
+) PRIMARY KEY (id);
+
+CREATE TABLE post_tag (
+  post_id STRING(36) NOT NULL,
+  tag_id STRING(36) NOT NULL,
+  FOREIGN KEY (tag_id) REFERENCES tag (id),
+) PRIMARY KEY (post_id, tag_id), INTERLEAVE IN PARENT post ON DELETE CASCADE;
+
+CREATE UNIQUE INDEX idx_user_name ON user (name DESC) STORING (manager_id);
+`,
+			want: plate.Schema{
+				Tables: []plate.TableConfig{
+					{
+						Schema: plate.TableSchema{
+							TableName: "user",
+							TypeName:  "User",
+							Columns: []plate.ColumnSchema{
+								{Name: "ID", ColumnName: "id", SpannerType: "STRING(36)", GoType: "string", NotNull: true},
+								{Name: "Name", ColumnName: "name", SpannerType: "STRING(MAX)", GoType: "string"},
+								{Name: "ManagerID", ColumnName: "manager_id", SpannerType: "STRING(36)", GoType: "string"},
+							},
+							PrimaryKey: []plate.IndexKey{{Column: "id"}},
+							Indexes: []plate.IndexSchema{
+								{Name: "idx_user_name", Keys: []plate.IndexKey{{Column: "name", Desc: true}}, Unique: true, Storing: []string{"manager_id"}},
+							},
+						},
+						Relations: []plate.Relation{
+							{Name: "Manager", Target: "User", From: "ManagerID", To: "ID", ReverseName: "Users"},
+						},
+					},
+					{
+						Schema: plate.TableSchema{
+							TableName: "post",
+							TypeName:  "Post",
+							Columns: []plate.ColumnSchema{
+								{Name: "ID", ColumnName: "id", SpannerType: "STRING(36)", GoType: "string", NotNull: true},
+								{Name: "UserID", ColumnName: "user_id", SpannerType: "STRING(36)", GoType: "string", NotNull: true},
+								{Name: "CreatedAt", ColumnName: "created_at", SpannerType: "TIMESTAMP", GoType: "time.Time", NotNull: true},
+							},
+							PrimaryKey: []plate.IndexKey{{Column: "id"}},
+						},
+						Relations: []plate.Relation{
+							{Name: "User", Target: "User", From: "UserID", To: "ID", ReverseName: "Posts"},
+						},
+					},
+					{
+						Schema: plate.TableSchema{
+							TableName: "tag",
+							TypeName:  "Tag",
+							Columns: []plate.ColumnSchema{
+								{Name: "ID", ColumnName: "id", SpannerType: "STRING(36)", GoType: "string", NotNull: true},
+							},
+							PrimaryKey: []plate.IndexKey{{Column: "id"}},
+						},
+					},
+				},
+				Junctions: []plate.JunctionConfig{
+					{
+						Schema: plate.TableSchema{
+							TableName: "post_tag",
+							TypeName:  "PostTag",
+							Columns: []plate.ColumnSchema{
+								{Name: "PostID", ColumnName: "post_id", SpannerType: "STRING(36)", GoType: "string", NotNull: true},
+								{Name: "TagID", ColumnName: "tag_id", SpannerType: "STRING(36)", GoType: "string", NotNull: true},
+							},
+							PrimaryKey:      []plate.IndexKey{{Column: "post_id"}, {Column: "tag_id"}},
+							Parent:          "post",
+							OnDeleteCascade: true,
+						},
+						Relations: []plate.Relation{
+							{Name: "Post", Target: "Post", From: "PostID", To: "ID", ReverseName: "Tags"},
+							{Name: "Tag", Target: "Tag", From: "TagID", To: "ID", ReverseName: "Posts"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "migrations applied in order",
+			ddl: `
+CREATE TABLE Singers (
+  SingerId INT64 NOT NULL,
+  FirstName STRING(1024),
+  Nickname STRING(MAX),
+) PRIMARY KEY (SingerId);
+
+CREATE TABLE Albums (
+  SingerId INT64 NOT NULL,
+  AlbumId INT64 NOT NULL,
+  Title STRING(MAX),
+) PRIMARY KEY (SingerId, AlbumId), INTERLEAVE IN PARENT Singers;
+
+ALTER TABLE Singers ADD COLUMN Labels ARRAY<STRING(MAX)>;
+ALTER TABLE Singers ADD COLUMN Info JSON;
+ALTER TABLE Singers DROP COLUMN Nickname;
+ALTER TABLE Albums ALTER COLUMN Title STRING(MAX) NOT NULL;
+CREATE INDEX AlbumsByTitle ON Albums (Title);
+DROP INDEX AlbumsByTitle;
+`,
+			want: plate.Schema{
+				Tables: []plate.TableConfig{
+					{
+						Schema: plate.TableSchema{
+							TableName: "Singers",
+							TypeName:  "Singers",
+							Columns: []plate.ColumnSchema{
+								{Name: "SingerId", ColumnName: "SingerId", SpannerType: "INT64", GoType: "int64", NotNull: true},
+								{Name: "FirstName", ColumnName: "FirstName", SpannerType: "STRING(1024)", GoType: "string"},
+								{Name: "Labels", ColumnName: "Labels", SpannerType: "ARRAY<STRING(MAX)>", GoType: "[]string"},
+								{Name: "Info", ColumnName: "Info", SpannerType: "JSON", GoType: "spanner.NullJSON"},
+							},
+							PrimaryKey: []plate.IndexKey{{Column: "SingerId"}},
+						},
+					},
+					{
+						Schema: plate.TableSchema{
+							TableName: "Albums",
+							TypeName:  "Albums",
+							Columns: []plate.ColumnSchema{
+								{Name: "SingerId", ColumnName: "SingerId", SpannerType: "INT64", GoType: "int64", NotNull: true},
+								{Name: "AlbumId", ColumnName: "AlbumId", SpannerType: "INT64", GoType: "int64", NotNull: true},
+								{Name: "Title", ColumnName: "Title", SpannerType: "STRING(MAX)", GoType: "string", NotNull: true},
+							},
+							PrimaryKey: []plate.IndexKey{{Column: "SingerId"}, {Column: "AlbumId"}},
+							Indexes:    []plate.IndexSchema{},
+							Parent:     "Singers",
+						},
+						Relations: []plate.Relation{
+							{Name: "Singer", Target: "Singers", From: "SingerId", To: "SingerId", ReverseName: "Albums"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "unknown referenced table",
+			ddl:     "CREATE TABLE post (id STRING(36), user_id STRING(36), FOREIGN KEY (user_id) REFERENCES user (id)) PRIMARY KEY (id)",
+			wantErr: "<input>:1:1: referenced table user does not exist",
+		},
+		{
+			name:    "index on unknown column",
+			ddl:     "CREATE TABLE tag (id STRING(36)) PRIMARY KEY (id);\nCREATE INDEX idx ON tag (name)",
+			wantErr: "<input>:2:1: column name does not exist in table tag",
+		},
+		{
+			name:    "syntax error",
+			ddl:     "CREATE TABLE tag (id STRING(36)",
+			wantErr: "failed to parse DDL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := plate.SchemaFromDDL(strings.NewReader(tt.ddl))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Error mismatch\ngot:  %v\nwant: %s", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Schema mismatch\ngot:  %+v\nwant: %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGenerateFromDDL(t *testing.T) {
+	dir := t.TempDir()
+	migrations := []struct {
+		file string
+		ddl  string
+	}{
+		{"001_singers.sql", "CREATE TABLE Singers (SingerId INT64 NOT NULL, Name STRING(MAX)) PRIMARY KEY (SingerId);"},
+		{"002_albums.sql", "CREATE TABLE Albums (SingerId INT64 NOT NULL, AlbumId INT64 NOT NULL, ReleaseDate DATE) PRIMARY KEY (SingerId, AlbumId), INTERLEAVE IN PARENT Singers;"},
+	}
+	var paths []string
+	for _, m := range migrations {
+		path := filepath.Join(dir, m.file)
+		if err := os.WriteFile(path, []byte(m.ddl), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	schema, err := plate.SchemaFromDDLFiles(paths...)
+	if err != nil {
+		t.Fatalf("Failed to load DDL: %v", err)
+	}
+
+	files, err := plate.NewGenerator().GenerateFiles(schema, "./generated")
+	if err != nil {
+		t.Fatalf("Failed to generate files: %v", err)
+	}
+
+	tests := []struct {
+		file string
+		want []string
+	}{
+		{
+			file: "singers/singers.go",
+			want: []string{
+				`return types.NumericColumn[tables.Singers, int64]{Column: types.Column[tables.Singers, int64]{Name: "SingerId"}}`,
+				`query.WithMany[tables.Singers, tables.Albums](`,
+				`query.KeyPair{From: "SingerId", To: "SingerId"},`,
+			},
+		},
+		{
+			file: "albums/albums.go",
+			want: []string{
+				`"cloud.google.com/go/civil"`,
+				`func ReleaseDate() types.DateColumn[tables.Albums, civil.Date] {`,
+				`query.WithOne[tables.Albums, tables.Singers](`,
+				`"Singers",`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		code, ok := files.Files[tt.file]
+		if !ok {
+			t.Fatalf("File %s was not generated", tt.file)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(code, want) {
+				t.Errorf("%s does not contain %s", tt.file, want)
+			}
+		}
+	}
+}
+
+func TestColumnKinds(t *testing.T) {
+	schema, err := plate.SchemaFromDDL(strings.NewReader(`
+CREATE TABLE item (
+  id STRING(36) NOT NULL,
+  name STRING(MAX),
+  count INT64 NOT NULL,
+  price NUMERIC,
+  active BOOL NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  released DATE,
+  data BYTES(MAX),
+  tags ARRAY<STRING(MAX)>,
+  attrs JSON,
+) PRIMARY KEY (id);
+`))
+	if err != nil {
+		t.Fatalf("Failed to load DDL: %v", err)
+	}
+	schema.Tables = append(schema.Tables, plate.TableConfig{Schema: plate.TableSchema{
+		TableName: "raw",
+		TypeName:  "Raw",
+		Columns:   []plate.ColumnSchema{{Name: "Value", ColumnName: "value", GoType: "spanner.GenericColumnValue"}},
+	}})
+
+	files, err := plate.NewGenerator().GenerateFiles(schema, "./generated")
+	if err != nil {
+		t.Fatalf("Failed to generate files: %v", err)
+	}
+
+	tests := []struct {
+		file string
+		want string
+	}{
+		{"item/item.go", "func Name() types.StringColumn[tables.Item, string] {"},
+		{"item/item.go", "func Count() types.NumericColumn[tables.Item, int64] {"},
+		{"item/item.go", "func Price() types.DecimalColumn[tables.Item, big.Rat] {"},
+		{"item/item.go", "return types.DecimalColumn[tables.Item, big.Rat]{NumericColumn: types.NumericColumn[tables.Item, big.Rat]{Column: types.Column[tables.Item, big.Rat]{Name: \"price\"}}}"},
+		{"item/item.go", "func Active() types.BoolColumn[tables.Item, bool] {"},
+		{"item/item.go", "func CreatedAt() types.TimeColumn[tables.Item, time.Time] {"},
+		{"item/item.go", "func Released() types.DateColumn[tables.Item, civil.Date] {"},
+		{"item/item.go", "func Data() types.BytesColumn[tables.Item, []byte] {"},
+		{"item/item.go", "return types.ArrayColumn[tables.Item, string]{BaseColumn: types.BaseColumn[tables.Item, []string]{Name: \"tags\"}}"},
+		{"item/item.go", "func Attrs() types.JSONColumn[tables.Item, spanner.NullJSON] {"},
+		{"item/item.go", "return types.JSONColumn[tables.Item, spanner.NullJSON]{BaseColumn: types.BaseColumn[tables.Item, spanner.NullJSON]{Name: \"attrs\"}}"},
+		{"raw/raw.go", "return types.Column[tables.Raw, spanner.GenericColumnValue]{Name: \"value\"}"},
+	}
+	for _, tt := range tests {
+		if !strings.Contains(files.Files[tt.file], tt.want) {
+			t.Errorf("%s does not contain %s", tt.file, tt.want)
+		}
+	}
+}
+
+func TestGenerateModelsFromDDL(t *testing.T) {
+	schema, err := plate.SchemaFromDDL(strings.NewReader(`
+CREATE TABLE user (
+  id STRING(36) NOT NULL,
+  name STRING(MAX),
+  age INT64,
+  balance NUMERIC NOT NULL,
+  profile JSON,
+  birthday DATE,
+  avatar BYTES(MAX),
+  scores ARRAY<FLOAT64>,
+  created_at TIMESTAMP NOT NULL,
+) PRIMARY KEY (id);
+
+CREATE TABLE tag (
+  id STRING(36) NOT NULL,
+) PRIMARY KEY (id);
+`))
+	if err != nil {
+		t.Fatalf("Failed to load DDL: %v", err)
+	}
+
+	files, err := plate.NewGenerator().GenerateModelFiles(schema)
+	if err != nil {
+		t.Fatalf("Failed to generate models: %v", err)
+	}
+
+	got, err := format.Source([]byte(files.Files["models/models.go"]))
+	if err != nil {
+		t.Fatalf("Generated models do not compile: %v", err)
+	}
+
+	want := "// Code generated by plate; DO NOT EDIT.\n" +
+		"\n" +
+		"package models\n" +
+		"\n" +
+		"import (\n" +
+		"\t\"cloud.google.com/go/spanner\"\n" +
+		"\t\"math/big\"\n" +
+		"\t\"time\"\n" +
+		")\n" +
+		"\n" +
+		"// User represents a row of the user table\n" +
+		"type User struct {\n" +
+		"\tID        string                `spanner:\"id\" spannerType:\"STRING(36)\"`\n" +
+		"\tName      spanner.NullString    `spanner:\"name\" spannerType:\"STRING(MAX)\"`\n" +
+		"\tAge       spanner.NullInt64     `spanner:\"age\" spannerType:\"INT64\"`\n" +
+		"\tBalance   big.Rat               `spanner:\"balance\" spannerType:\"NUMERIC\"`\n" +
+		"\tProfile   spanner.NullJSON      `spanner:\"profile\" spannerType:\"JSON\"`\n" +
+		"\tBirthday  spanner.NullDate      `spanner:\"birthday\" spannerType:\"DATE\"`\n" +
+		"\tAvatar    []byte                `spanner:\"avatar\" spannerType:\"BYTES(MAX)\"`\n" +
+		"\tScores    []spanner.NullFloat64 `spanner:\"scores\" spannerType:\"ARRAY<FLOAT64>\"`\n" +
+		"\tCreatedAt time.Time             `spanner:\"created_at\" spannerType:\"TIMESTAMP\"`\n" +
+		"}\n" +
+		"\n" +
+		"// Tag represents a row of the tag table\n" +
+		"type Tag struct {\n" +
+		"\tID string `spanner:\"id\" spannerType:\"STRING(36)\"`\n" +
+		"}\n"
+	if string(got) != want {
+		t.Errorf("Models mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGenerateModelsMatchAccessors(t *testing.T) {
+	schema, err := plate.SchemaFromDDL(strings.NewReader(`
+CREATE TABLE user (
+  id STRING(36) NOT NULL,
+  name STRING(MAX),
+  age INT64,
+  balance NUMERIC,
+  birthday DATE,
+  scores ARRAY<FLOAT64>,
+  created_at TIMESTAMP NOT NULL,
+) PRIMARY KEY (id);
+`))
+	if err != nil {
+		t.Fatalf("Failed to load DDL: %v", err)
+	}
+
+	// The output must be inside the module to resolve the import path of the tables package
+	dir, err := os.MkdirTemp(filepath.Join("testdata", "models"), "generated-")
+	if err != nil {
+		t.Fatalf("Failed to create output directory: %v", err)
+	}
+	t.Cleanup(func() { os.RemoveAll(dir) })
+	if err := plate.NewGenerator().Generate(schema, plate.GenerateOptions{OutputDir: dir, Models: true}); err != nil {
+		t.Fatalf("Failed to generate: %v", err)
+	}
+	models, err := os.ReadFile(filepath.Join(dir, "models", "models.go"))
+	if err != nil {
+		t.Fatalf("Failed to read models: %v", err)
+	}
+	builder, err := os.ReadFile(filepath.Join(dir, "user", "user.go"))
+	if err != nil {
+		t.Fatalf("Failed to read query builder: %v", err)
+	}
+
+	fields := make(map[string]string)
+	for _, m := range regexp.MustCompile("(?m)^\\t(\\w+)\\s+(\\S+)\\s+`spanner:").FindAllStringSubmatch(string(models), -1) {
+		fields[m[1]] = m[2]
+	}
+	accessors := regexp.MustCompile(`func (\w+)\(\) types\.(\w+)Column\[tables\.User, (.+)\] \{`).FindAllStringSubmatch(string(builder), -1)
+	if len(accessors) != 7 || len(fields) != 7 {
+		t.Fatalf("Expected 7 accessors and fields, got %d and %d\n%s\n%s", len(accessors), len(fields), builder, models)
+	}
+	for _, m := range accessors {
+		name, kind, valueType := m[1], m[2], m[3]
+		if kind == "Array" {
+			valueType = "[]" + valueType
+		}
+		if fields[name] != valueType {
+			t.Errorf("%s: accessor takes %s, model field is %s", name, valueType, fields[name])
+		}
+	}
+	if fields["Name"] != "spanner.NullString" || fields["Balance"] != "spanner.NullNumeric" {
+		t.Errorf("Nullable columns should use Null* types, got %v", fields)
+	}
+}
+
+type ddlUser struct {
+	ID        string     `spanner:"id" spannerType:"STRING(36)"`
+	Name      NullString `spanner:"name" spannerType:"STRING"`
+	ManagerID *string    `spanner:"manager_id" spannerType:"STRING(36)"`
+	CreatedAt time.Time  `spanner:"created_at" spannerType:"TIMESTAMP"`
+}
+
+type ddlPost struct {
+	ID     string   `spanner:"id" spannerType:"STRING(36)"`
+	UserID string   `spanner:"user_id" spannerType:"STRING(36)"`
+	Tags   []string `spanner:"labels" spannerType:"ARRAY<STRING(MAX)>"`
+}
+
+type ddlPostTag struct {
+	PostID string `spanner:"post_id" spannerType:"STRING(36)"`
+	TagID  string `spanner:"tag_id" spannerType:"STRING(36)"`
+}
+
+type ddlTag struct {
+	ID   string `spanner:"id" spannerType:"STRING(36)"`
+	Name string `spanner:"name"`
+}
+
+// NullString stands in for spanner.NullString, Null* types are treated as nullable
+type NullString struct{}
+
+func TestGenerateDDL(t *testing.T) {
+	schema := plate.Schema{
+		Tables: []plate.TableConfig{
+			{
+				Schema: plate.TableSchema{TableName: "post", Model: ddlPost{}},
+				Relations: []plate.Relation{
+					{Name: "Author", Target: "ddlUser", From: "UserID", To: "ID", ReverseName: "Posts"},
+				},
+			},
+			{
+				Schema: plate.TableSchema{TableName: "user", Model: ddlUser{}},
+				Relations: []plate.Relation{
+					{Name: "Manager", Target: "ddlUser", From: "ManagerID", To: "ID", ReverseName: "Reports"},
+				},
+			},
+			{
+				Schema: plate.TableSchema{
+					TableName: "tag",
+					Model:     ddlTag{},
+					Indexes: []plate.IndexSchema{
+						{Name: "idx_tag_name", Keys: []plate.IndexKey{{Column: "name"}}, Unique: true},
+					},
+				},
+			},
+		},
+		Junctions: []plate.JunctionConfig{
+			{
+				Schema: plate.TableSchema{TableName: "post_tag", Model: ddlPostTag{}},
+				Relations: []plate.Relation{
+					{Name: "Post", Target: "ddlPost", From: "PostID", To: "ID", ReverseName: "Tags"},
+					{Name: "Tag", Target: "ddlTag", From: "TagID", To: "ID", ReverseName: "Posts"},
+				},
+			},
+		},
+	}
+
+	got, err := plate.NewGenerator().GenerateDDL(schema)
+	if err != nil {
+		t.Fatalf("Failed to generate DDL: %v", err)
+	}
+
+	want := `CREATE TABLE post (
+  id STRING(36) NOT NULL,
+  user_id STRING(36) NOT NULL,
+  labels ARRAY<STRING(MAX)>
+) PRIMARY KEY (id);
+
+CREATE TABLE user (
+  id STRING(36) NOT NULL,
+  name STRING(MAX),
+  manager_id STRING(36),
+  created_at TIMESTAMP NOT NULL,
+  CONSTRAINT FK_user_manager FOREIGN KEY (manager_id) REFERENCES user (id)
+) PRIMARY KEY (id);
+
+CREATE TABLE tag (
+  id STRING(36) NOT NULL,
+  name STRING(MAX) NOT NULL
+) PRIMARY KEY (id);
+
+CREATE UNIQUE INDEX idx_tag_name ON tag(name);
+
+CREATE TABLE post_tag (
+  post_id STRING(36) NOT NULL,
+  tag_id STRING(36) NOT NULL,
+  CONSTRAINT FK_post_tag_post FOREIGN KEY (post_id) REFERENCES post (id),
+  CONSTRAINT FK_post_tag_tag FOREIGN KEY (tag_id) REFERENCES tag (id)
+) PRIMARY KEY (post_id, tag_id);
+
+ALTER TABLE post ADD CONSTRAINT FK_post_author FOREIGN KEY (user_id) REFERENCES user (id);
+`
+	if got != want {
+		t.Errorf("DDL mismatch\ngot:\n%s\nwant:\n%s", got, want)
+	}
+
+	// The output parses back to the same tables
+	parsed, err := plate.SchemaFromDDL(strings.NewReader(got))
+	if err != nil {
+		t.Fatalf("Generated DDL does not parse: %v", err)
+	}
+	if len(parsed.Tables) != 3 || len(parsed.Junctions) != 1 {
+		t.Errorf("Parsed %d tables and %d junctions, want 3 and 1", len(parsed.Tables), len(parsed.Junctions))
+	}
+}
+
+func TestDDLRoundTrip(t *testing.T) {
+	ddl := `CREATE TABLE Singers (
+  SingerId INT64 NOT NULL,
+  Name STRING(1024),
+  Info JSON
+) PRIMARY KEY (SingerId);
+
+CREATE INDEX SingersByName ON Singers(Name DESC) STORING (Info);
+
+CREATE TABLE Albums (
+  SingerId INT64 NOT NULL,
+  AlbumId INT64 NOT NULL,
+  Price NUMERIC,
+  ProducerId INT64,
+  CONSTRAINT FK_Albums_producer FOREIGN KEY (ProducerId) REFERENCES Singers (SingerId)
+) PRIMARY KEY (SingerId, AlbumId),
+  INTERLEAVE IN PARENT Singers ON DELETE CASCADE;
+`
+	schema, err := plate.SchemaFromDDL(strings.NewReader(ddl))
+	if err != nil {
+		t.Fatalf("Failed to load DDL: %v", err)
+	}
+
+	got, err := plate.NewGenerator().GenerateDDL(schema)
+	if err != nil {
+		t.Fatalf("Failed to generate DDL: %v", err)
+	}
+	if got != ddl {
+		t.Errorf("DDL mismatch\ngot:\n%s\nwant:\n%s", got, ddl)
+	}
+}
+
+func TestCheckSchema(t *testing.T) {
+	schema := plate.Schema{
+		Tables: []plate.TableConfig{
+			{
+				Schema: plate.TableSchema{TableName: "user", Model: ddlUser{}},
+				Relations: []plate.Relation{
+					{Name: "Manager", Target: "ddlUser", From: "ManagerID", To: "ID"},
+				},
+			},
+			{
+				Schema: plate.TableSchema{TableName: "post", Model: ddlPost{}},
+				Relations: []plate.Relation{
+					{Name: "Author", Target: "ddlUser", From: "UserID", To: "ID"},
+				},
+			},
+			{
+				Schema: plate.TableSchema{TableName: "tag", Model: ddlTag{}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		ddl  string
+		want []string
+	}{
+		{
+			name: "matching DDL",
+			ddl: `
+CREATE TABLE user (id STRING(36) NOT NULL, name STRING(MAX), manager_id STRING(36), created_at TIMESTAMP NOT NULL,
+  FOREIGN KEY (manager_id) REFERENCES user (id)) PRIMARY KEY (id);
+CREATE TABLE post (id STRING(36) NOT NULL, user_id STRING(36) NOT NULL, labels ARRAY<STRING(MAX)>, extra INT64) PRIMARY KEY (user_id, id),
+  INTERLEAVE IN PARENT user;
+CREATE TABLE tag (id STRING(36) NOT NULL, name STRING(255) NOT NULL) PRIMARY KEY (id);
+`,
+			want: nil,
+		},
+		{
+			name: "drifted DDL",
+			ddl: `
+CREATE TABLE user (id STRING(64) NOT NULL, name STRING(MAX) NOT NULL, manager_id STRING(36), created_at TIMESTAMP NOT NULL) PRIMARY KEY (id);
+CREATE TABLE post (id STRING(36) NOT NULL, user_id STRING(36), labels ARRAY<INT64>) PRIMARY KEY (id);
+`,
+			want: []string{
+				"user.id: type mismatch: field ID is STRING(36), column is STRING(64)",
+				"user.name: nullability mismatch: field Name of type plate_test.NullString is nullable, column is NOT NULL",
+				"user.manager_id: missing foreign key: relation Manager has no FOREIGN KEY (manager_id) REFERENCES user (id) or INTERLEAVE IN PARENT user",
+				"post.user_id: nullability mismatch: field UserID of type string cannot hold NULL, column is nullable",
+				"post.labels: type mismatch: field Tags is ARRAY<STRING(MAX)>, column is ARRAY<INT64>",
+				"post.user_id: missing foreign key: relation Author has no FOREIGN KEY (user_id) REFERENCES user (id) or INTERLEAVE IN PARENT user",
+				"tag: missing table: table does not exist in the DDL",
+			},
+		},
+		{
+			name: "missing column",
+			ddl: `
+CREATE TABLE user (id STRING(36) NOT NULL, name STRING(MAX), manager_id STRING(36), created_at TIMESTAMP NOT NULL,
+  FOREIGN KEY (manager_id) REFERENCES user (id)) PRIMARY KEY (id);
+CREATE TABLE post (id STRING(36) NOT NULL, user_id STRING(36) NOT NULL, FOREIGN KEY (user_id) REFERENCES user (id)) PRIMARY KEY (id);
+CREATE TABLE tag (id STRING(36) NOT NULL, name STRING(MAX) NOT NULL) PRIMARY KEY (id);
+`,
+			want: []string{
+				"post.labels: missing column: field Tags has no column in the DDL",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			drifts, err := plate.CheckSchema(schema, strings.NewReader(tt.ddl))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			var got []string
+			for _, drift := range drifts {
+				got = append(got, drift.String())
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Drift mismatch\ngot:  %q\nwant: %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffSchema(t *testing.T) {
+	schema := plate.Schema{
+		Tables: []plate.TableConfig{
+			{
+				Schema: plate.TableSchema{TableName: "user", Model: ddlUser{}},
+				Relations: []plate.Relation{
+					{Name: "Manager", Target: "ddlUser", From: "ManagerID", To: "ID"},
+				},
+			},
+			{
+				Schema: plate.TableSchema{TableName: "post", Model: ddlPost{}},
+				Relations: []plate.Relation{
+					{Name: "Author", Target: "ddlUser", From: "UserID", To: "ID"},
+				},
+			},
+			{
+				Schema: plate.TableSchema{
+					TableName: "tag",
+					Model:     ddlTag{},
+					Indexes: []plate.IndexSchema{
+						{Name: "idx_tag_name", Keys: []plate.IndexKey{{Column: "name"}}, Unique: true},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name       string
+		ddl        string
+		wantSQL    string
+		wantReview []string
+	}{
+		{
+			name: "up to date",
+			ddl: `
+CREATE TABLE user (id STRING(36) NOT NULL, name STRING(MAX), manager_id STRING(36), created_at TIMESTAMP NOT NULL,
+  FOREIGN KEY (manager_id) REFERENCES user (id)) PRIMARY KEY (id);
+CREATE TABLE post (id STRING(36) NOT NULL, user_id STRING(36) NOT NULL, labels ARRAY<STRING(MAX)>,
+  FOREIGN KEY (user_id) REFERENCES user (id)) PRIMARY KEY (id);
+CREATE TABLE tag (id STRING(36) NOT NULL, name STRING(MAX) NOT NULL) PRIMARY KEY (id);
+CREATE UNIQUE INDEX idx_tag_name ON tag (name);
+`,
+		},
+		{
+			name: "additive changes",
+			ddl: `
+CREATE TABLE user (id STRING(36) NOT NULL, name STRING(MAX) NOT NULL, created_at TIMESTAMP NOT NULL) PRIMARY KEY (id);
+CREATE TABLE post (id STRING(36) NOT NULL, user_id STRING(36) NOT NULL) PRIMARY KEY (id);
+`,
+			wantSQL: `CREATE TABLE tag (
+  id STRING(36) NOT NULL,
+  name STRING(MAX) NOT NULL
+) PRIMARY KEY (id);
+
+ALTER TABLE user ALTER COLUMN name STRING(MAX);
+
+ALTER TABLE user ADD COLUMN manager_id STRING(36);
+
+ALTER TABLE post ADD COLUMN labels ARRAY<STRING(MAX)>;
+
+CREATE UNIQUE INDEX idx_tag_name ON tag(name);
+
+ALTER TABLE user ADD CONSTRAINT FK_user_manager FOREIGN KEY (manager_id) REFERENCES user (id);
+
+ALTER TABLE post ADD CONSTRAINT FK_post_author FOREIGN KEY (user_id) REFERENCES user (id);
+`,
+		},
+		{
+			name: "destructive changes",
+			ddl: `
+CREATE TABLE user (id STRING(64) NOT NULL, name STRING(MAX), manager_id STRING(36), created_at TIMESTAMP NOT NULL, age INT64,
+  FOREIGN KEY (manager_id) REFERENCES user (id)) PRIMARY KEY (id);
+CREATE INDEX idx_user_age ON user (age);
+CREATE TABLE post (id STRING(36) NOT NULL, user_id STRING(36), labels ARRAY<STRING(MAX)>,
+  FOREIGN KEY (user_id) REFERENCES user (id)) PRIMARY KEY (id);
+CREATE TABLE tag (id STRING(36) NOT NULL) PRIMARY KEY (id);
+CREATE UNIQUE INDEX idx_tag_name ON tag (id);
+CREATE TABLE legacy (id INT64 NOT NULL) PRIMARY KEY (id);
+`,
+			wantSQL: `ALTER TABLE tag ADD COLUMN name STRING(MAX);
+`,
+			wantReview: []string{
+				"user.id: type mismatch: changing the type from STRING(64) to STRING(36) needs manual review",
+				"user.age: extra column: column is not in the schema, DROP COLUMN needs manual review",
+				"user: extra index: index idx_user_age is not in the schema, DROP INDEX needs manual review",
+				"post.user_id: nullability mismatch: adding NOT NULL needs manual review, existing rows may hold NULL",
+				"tag.name: nullability mismatch: column is added as nullable, backfill it and then ALTER COLUMN name STRING(MAX) NOT NULL",
+				"legacy: extra table: table is not in the schema, DROP TABLE needs manual review",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			migration, err := plate.DiffSchema(schema, strings.NewReader(tt.ddl))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if got := migration.SQL(); got != tt.wantSQL {
+				t.Errorf("SQL mismatch\ngot:\n%s\nwant:\n%s", got, tt.wantSQL)
+			}
+			var review []string
+			for _, drift := range migration.Review {
+				review = append(review, drift.String())
+			}
+			if !reflect.DeepEqual(review, tt.wantReview) {
+				t.Errorf("Review mismatch\ngot:  %q\nwant: %q", review, tt.wantReview)
+			}
+
+			// Applying the migration leaves only the changes under review
+			if tt.wantReview == nil {
+				drifts, err := plate.CheckSchema(schema, strings.NewReader(tt.ddl+"\n"+migration.SQL()))
+				if err != nil {
+					t.Fatalf("Migration does not apply: %v", err)
+				}
+				if len(drifts) > 0 {
+					t.Errorf("Drift after migration: %v", drifts)
+				}
+			}
+		})
+	}
+}
+
+func TestRunCheck(t *testing.T) {
+	schema := plate.Schema{
+		Tables: []plate.TableConfig{
+			{Schema: plate.TableSchema{TableName: "tag", Model: ddlTag{}}},
+		},
+	}
+	dir := t.TempDir()
+	matching := filepath.Join(dir, "matching.sql")
+	drifted := filepath.Join(dir, "drifted.sql")
+	if err := os.WriteFile(matching, []byte("CREATE TABLE tag (id STRING(36) NOT NULL, name STRING(MAX) NOT NULL) PRIMARY KEY (id)"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(drifted, []byte("CREATE TABLE tag (id STRING(36) NOT NULL) PRIMARY KEY (id)"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantStatus int
+		wantOutput string
+	}{
+		{name: "no drift", args: []string{"check", matching}, wantStatus: 0},
+		{name: "drift", args: []string{"check", drifted}, wantStatus: 1, wantOutput: "tag.name: missing column: field Name has no column in the DDL\n"},
+		{name: "missing DDL files", args: []string{"check"}, wantStatus: 2},
+		{name: "diff", args: []string{"diff", drifted}, wantStatus: 1, wantOutput: "ALTER TABLE tag ADD COLUMN name STRING(MAX);\n"},
+		{name: "no diff", args: []string{"diff", matching}, wantStatus: 0},
+		{name: "unknown command", args: []string{"migrate"}, wantStatus: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr strings.Builder
+			status := plate.Run(schema, plate.GenerateOptions{}, tt.args, &stdout, &stderr)
+			if status != tt.wantStatus {
+				t.Errorf("Status mismatch\ngot:  %d\nwant: %d\nstderr: %s", status, tt.wantStatus, stderr.String())
+			}
+			if stdout.String() != tt.wantOutput {
+				t.Errorf("Output mismatch\ngot:  %q\nwant: %q", stdout.String(), tt.wantOutput)
+			}
+		})
+	}
+}
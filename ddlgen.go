@@ -0,0 +1,315 @@
+package plate
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/cloudspannerecosystem/memefish/token"
+)
+
+// GenerateDDL renders the schema as Spanner DDL statements separated by semicolons
+func (g *Generator) GenerateDDL(schema Schema) (string, error) {
+	ddls, err := g.DDLStatements(schema)
+	if err != nil {
+		return "", err
+	}
+	return renderDDL(ddls), nil
+}
+
+// renderDDL renders statements separated by semicolons and blank lines
+func renderDDL(ddls []ast.DDL) string {
+	var sb strings.Builder
+	for i, ddl := range ddls {
+		if i > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(ddl.SQL())
+		sb.WriteString(";\n")
+	}
+	return sb.String()
+}
+
+// DDLStatements builds CREATE TABLE and CREATE INDEX statements for the schema
+// Column types come from spannerType tags, and relations become FOREIGN KEY constraints,
+// except the relation to the parent of an interleaved table. Parents are created before
+// their children, and foreign keys to tables created later are added with ALTER TABLE
+func (g *Generator) DDLStatements(schema Schema) ([]ast.DDL, error) {
+	schema, err := g.withTagRelations(schema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	g.schema = schema
+	if err := g.validateConfig(); err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	tableMap := g.buildTableMap()
+
+	var tables []ddlSource
+	for _, tc := range schema.Tables {
+		tables = append(tables, ddlSource{schema: tc.Schema, relations: tc.Relations})
+	}
+	for _, jc := range schema.Junctions {
+		tables = append(tables, ddlSource{schema: jc.Schema, relations: jc.Relations, junction: true})
+	}
+
+	ordered, err := orderByParent(tables, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ddls, deferred []ast.DDL
+	created := make(map[string]bool)
+	for _, src := range ordered {
+		typeName := g.getTypeName(src.schema)
+		ct, err := g.createTable(src, tableMap)
+		if err != nil {
+			return nil, fmt.Errorf("table %s: %w", src.schema.TableName, err)
+		}
+		created[typeName] = true
+
+		for _, rel := range src.relations {
+			if g.isParentRelation(src.schema, typeName, rel, tableMap) {
+				continue
+			}
+			fk := g.foreignKey(src.schema.TableName, typeName, rel, tableMap)
+			if created[rel.Target] {
+				ct.TableConstraints = append(ct.TableConstraints, fk)
+				continue
+			}
+			deferred = append(deferred, &ast.AlterTable{
+				Name:            ct.Name,
+				TableAlteration: &ast.AddTableConstraint{TableConstraint: fk},
+			})
+		}
+
+		ddls = append(ddls, ct)
+		for _, index := range src.schema.Indexes {
+			ddls = append(ddls, createIndex(src.schema.TableName, index))
+		}
+	}
+
+	return append(ddls, deferred...), nil
+}
+
+// ddlSource is a table or junction table to create
+type ddlSource struct {
+	schema    TableSchema
+	relations []Relation
+	junction  bool
+}
+
+// createTable builds the CREATE TABLE statement of a table without its foreign keys
+func (g *Generator) createTable(src ddlSource, tableMap map[string]TableSchema) (*ast.CreateTable, error) {
+	typeName := g.getTypeName(src.schema)
+	ct := &ast.CreateTable{
+		Name: &ast.Path{Idents: []*ast.Ident{{Name: src.schema.TableName}}},
+	}
+
+	for _, col := range g.getColumns(src.schema) {
+		def, err := columnDef(col)
+		if err != nil {
+			return nil, err
+		}
+		ct.Columns = append(ct.Columns, def)
+	}
+
+	// Primary key: explicit keys, the two foreign keys of a junction table, or the ID column
+	keys := src.schema.PrimaryKey
+	switch {
+	case len(keys) > 0:
+	case src.junction && len(src.relations) == 2:
+		for _, rel := range src.relations {
+			keys = append(keys, IndexKey{Column: g.columnName(tableMap, typeName, rel.From)})
+		}
+	default:
+		for _, col := range g.getColumns(src.schema) {
+			if col.Name == "ID" {
+				keys = append(keys, IndexKey{Column: col.ColumnName})
+			}
+		}
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no primary key, set PrimaryKey or add an ID column")
+	}
+	ct.PrimaryKeys = astIndexKeys(keys)
+
+	if src.schema.Parent != "" {
+		ct.Cluster = &ast.Cluster{
+			TableName: &ast.Path{Idents: []*ast.Ident{{Name: src.schema.Parent}}},
+			Enforced:  true,
+		}
+		if src.schema.OnDeleteCascade {
+			ct.Cluster.OnDelete = ast.OnDeleteCascade
+		}
+	}
+
+	return ct, nil
+}
+
+// columnDef builds the column definition of a column
+func columnDef(col columnInfo) (*ast.ColumnDef, error) {
+	if col.SpannerType == "" {
+		return nil, fmt.Errorf("column %s has no Spanner type, add a spannerType tag", col.ColumnName)
+	}
+	schemaType, err := parseSpannerType(col.SpannerType)
+	if err != nil {
+		return nil, fmt.Errorf("column %s: %w", col.ColumnName, err)
+	}
+	return &ast.ColumnDef{
+		Name:    &ast.Ident{Name: col.ColumnName},
+		Type:    schemaType,
+		NotNull: col.NotNull,
+		Null:    token.InvalidPos,
+		Key:     token.InvalidPos,
+		Hidden:  token.InvalidPos,
+	}, nil
+}
+
+// foreignKey builds the FOREIGN KEY constraint of a relation, named FK_<table>_<relation>
+func (g *Generator) foreignKey(tableName, typeName string, rel Relation, tableMap map[string]TableSchema) *ast.TableConstraint {
+	return &ast.TableConstraint{
+		Name: &ast.Ident{Name: fmt.Sprintf("FK_%s_%s", tableName, toSnakeCase(rel.Name))},
+		Constraint: &ast.ForeignKey{
+			Columns:          []*ast.Ident{{Name: g.columnName(tableMap, typeName, rel.From)}},
+			ReferenceTable:   &ast.Path{Idents: []*ast.Ident{{Name: g.tableName(tableMap, rel.Target)}}},
+			ReferenceColumns: []*ast.Ident{{Name: g.columnName(tableMap, rel.Target, rel.To)}},
+		},
+	}
+}
+
+// isParentRelation reports whether the relation links an interleaved table to its parent
+// through the first primary key column, which INTERLEAVE IN PARENT already enforces
+func (g *Generator) isParentRelation(schema TableSchema, typeName string, rel Relation, tableMap map[string]TableSchema) bool {
+	if schema.Parent == "" || len(schema.PrimaryKey) == 0 {
+		return false
+	}
+	return strings.EqualFold(g.tableName(tableMap, rel.Target), schema.Parent) &&
+		strings.EqualFold(g.columnName(tableMap, typeName, rel.From), schema.PrimaryKey[0].Column)
+}
+
+// orderByParent orders tables so that every interleaved table follows its parent,
+// keeping the schema order otherwise
+// existing holds the lower-cased names of tables that already exist and may be parents
+func orderByParent(tables []ddlSource, existing map[string]bool) ([]ddlSource, error) {
+	known := make(map[string]bool)
+	created := make(map[string]bool)
+	for name := range existing {
+		known[name] = true
+		created[name] = true
+	}
+	for _, t := range tables {
+		known[strings.ToLower(t.schema.TableName)] = true
+	}
+
+	var ordered []ddlSource
+	done := make(map[string]bool)
+	for len(ordered) < len(tables) {
+		progress := false
+		for _, t := range tables {
+			name := strings.ToLower(t.schema.TableName)
+			parent := strings.ToLower(t.schema.Parent)
+			if done[name] || (parent != "" && !created[parent]) {
+				continue
+			}
+			ordered = append(ordered, t)
+			created[name] = true
+			done[name] = true
+			progress = true
+		}
+		if progress {
+			continue
+		}
+
+		for _, t := range tables {
+			if !done[strings.ToLower(t.schema.TableName)] && !known[strings.ToLower(t.schema.Parent)] {
+				return nil, fmt.Errorf("table %s: parent table %s is not in the schema", t.schema.TableName, t.schema.Parent)
+			}
+		}
+		return nil, fmt.Errorf("tables are interleaved in a cycle")
+	}
+	return ordered, nil
+}
+
+// createIndex builds the CREATE INDEX statement of an index
+func createIndex(tableName string, index IndexSchema) *ast.CreateIndex {
+	ci := &ast.CreateIndex{
+		Unique:       index.Unique,
+		NullFiltered: index.NullFiltered,
+		Name:         &ast.Path{Idents: []*ast.Ident{{Name: index.Name}}},
+		TableName:    &ast.Path{Idents: []*ast.Ident{{Name: tableName}}},
+		Keys:         astIndexKeys(index.Keys),
+	}
+	if len(index.Storing) > 0 {
+		ci.Storing = &ast.Storing{}
+		for _, col := range index.Storing {
+			ci.Storing.Columns = append(ci.Storing.Columns, &ast.Ident{Name: col})
+		}
+	}
+	return ci
+}
+
+// astIndexKeys converts primary key or index keys to their AST
+func astIndexKeys(keys []IndexKey) []*ast.IndexKey {
+	var result []*ast.IndexKey
+	for _, key := range keys {
+		ik := &ast.IndexKey{Name: &ast.Ident{Name: key.Column}}
+		if key.Desc {
+			ik.Dir = ast.DirectionDesc
+		}
+		result = append(result, ik)
+	}
+	return result
+}
+
+// parseSpannerType parses a Spanner column type such as "STRING(36)" or "ARRAY<INT64>"
+// STRING and BYTES without a length are treated as STRING(MAX) and BYTES(MAX)
+func parseSpannerType(s string) (ast.SchemaType, error) {
+	s = strings.ToUpper(strings.TrimSpace(s))
+
+	if inner, ok := strings.CutPrefix(s, "ARRAY<"); ok {
+		inner, ok = strings.CutSuffix(inner, ">")
+		if !ok {
+			return nil, fmt.Errorf("invalid Spanner type %s", s)
+		}
+		item, err := parseSpannerType(inner)
+		if err != nil {
+			return nil, err
+		}
+		if _, nested := item.(*ast.ArraySchemaType); nested {
+			return nil, fmt.Errorf("nested arrays are not supported: %s", s)
+		}
+		return &ast.ArraySchemaType{Item: item}, nil
+	}
+
+	name, size, sized := strings.Cut(s, "(")
+	typeName := ast.ScalarTypeName(strings.TrimSpace(name))
+	if _, ok := spannerGoTypes[typeName]; !ok {
+		return nil, fmt.Errorf("unsupported Spanner type %s", s)
+	}
+	if typeName != ast.StringTypeName && typeName != ast.BytesTypeName {
+		if sized {
+			return nil, fmt.Errorf("invalid Spanner type %s", s)
+		}
+		return &ast.ScalarSchemaType{Name: typeName}, nil
+	}
+
+	sizedType := &ast.SizedSchemaType{Name: typeName, Max: true}
+	if !sized {
+		return sizedType, nil
+	}
+	size, ok := strings.CutSuffix(size, ")")
+	size = strings.TrimSpace(size)
+	if !ok {
+		return nil, fmt.Errorf("invalid Spanner type %s", s)
+	}
+	if size != "MAX" {
+		if _, err := strconv.Atoi(size); err != nil {
+			return nil, fmt.Errorf("invalid length in Spanner type %s", s)
+		}
+		sizedType.Max = false
+		sizedType.Size = &ast.IntLiteral{Base: 10, Value: size}
+	}
+	return sizedType, nil
+}
@@ -0,0 +1,178 @@
+package plate
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// DriftKind classifies a difference between the Go schema and the DDL
+type DriftKind string
+
+const (
+	DriftMissingTable      DriftKind = "missing table"
+	DriftMissingColumn     DriftKind = "missing column"
+	DriftTypeMismatch      DriftKind = "type mismatch"
+	DriftNullability       DriftKind = "nullability mismatch"
+	DriftMissingForeignKey DriftKind = "missing foreign key"
+	DriftExtraTable        DriftKind = "extra table"
+	DriftExtraColumn       DriftKind = "extra column"
+	DriftExtraIndex        DriftKind = "extra index"
+)
+
+// Drift describes a difference between the Go schema and the DDL
+type Drift struct {
+	Kind    DriftKind
+	Table   string // Database table name
+	Column  string // Database column name, empty for table-level differences
+	Message string
+}
+
+// String formats the drift as "table.column: kind: message"
+func (d Drift) String() string {
+	location := d.Table
+	if d.Column != "" {
+		location += "." + d.Column
+	}
+	return fmt.Sprintf("%s: %s: %s", location, d.Kind, d.Message)
+}
+
+// lengthPattern matches the length of STRING(n) and BYTES(n) types
+var lengthPattern = regexp.MustCompile(`\([^)]*\)`)
+
+// CheckSchema compares the models of the schema against Spanner DDL and reports
+// missing tables and columns, type and nullability mismatches, and relations without
+// a matching foreign key. Columns that exist only in the DDL are not reported
+func CheckSchema(schema Schema, r io.Reader) ([]Drift, error) {
+	l, err := loadDDL(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewGenerator().checkDrift(schema, l)
+}
+
+// CheckSchemaFiles compares the models of the schema against Spanner DDL files applied in order
+func CheckSchemaFiles(schema Schema, paths ...string) ([]Drift, error) {
+	l, err := loadDDLFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+	return NewGenerator().checkDrift(schema, l)
+}
+
+// checkDrift compares every table and junction table of the schema with the loaded DDL
+func (g *Generator) checkDrift(schema Schema, l *ddlLoader) ([]Drift, error) {
+	schema, err := g.withTagRelations(schema)
+	if err != nil {
+		return nil, fmt.Errorf("invalid configuration: %w", err)
+	}
+	g.schema = schema
+	tableMap := g.buildTableMap()
+
+	var drifts []Drift
+	check := func(ts TableSchema, relations []Relation) {
+		t := l.table(ts.TableName)
+		if t == nil {
+			drifts = append(drifts, Drift{
+				Kind:    DriftMissingTable,
+				Table:   ts.TableName,
+				Message: "table does not exist in the DDL",
+			})
+			return
+		}
+
+		for _, col := range g.getColumns(ts) {
+			drifts = append(drifts, checkColumn(ts.TableName, col, t.column(col.ColumnName))...)
+		}
+
+		typeName := g.getTypeName(ts)
+		for _, rel := range relations {
+			from := g.columnName(tableMap, typeName, rel.From)
+			target := g.tableName(tableMap, rel.Target)
+			to := g.columnName(tableMap, rel.Target, rel.To)
+			if !l.hasReference(t, from, target, to) {
+				drifts = append(drifts, Drift{
+					Kind:    DriftMissingForeignKey,
+					Table:   ts.TableName,
+					Column:  from,
+					Message: fmt.Sprintf("relation %s has no FOREIGN KEY (%s) REFERENCES %s (%s) or INTERLEAVE IN PARENT %s", rel.Name, from, target, to, target),
+				})
+			}
+		}
+	}
+
+	for _, tc := range schema.Tables {
+		check(tc.Schema, tc.Relations)
+	}
+	for _, jc := range schema.Junctions {
+		check(jc.Schema, jc.Relations)
+	}
+	return drifts, nil
+}
+
+// checkColumn compares a model column with its DDL definition, which is nil when missing
+func checkColumn(table string, col columnInfo, def *ColumnSchema) []Drift {
+	if def == nil {
+		return []Drift{{
+			Kind:    DriftMissingColumn,
+			Table:   table,
+			Column:  col.ColumnName,
+			Message: fmt.Sprintf("field %s has no column in the DDL", col.Name),
+		}}
+	}
+
+	var drifts []Drift
+	if col.SpannerType != "" && !spannerTypesMatch(col.SpannerType, def.SpannerType) {
+		drifts = append(drifts, Drift{
+			Kind:    DriftTypeMismatch,
+			Table:   table,
+			Column:  col.ColumnName,
+			Message: fmt.Sprintf("field %s is %s, column is %s", col.Name, col.SpannerType, def.SpannerType),
+		})
+	}
+	if col.NotNull != def.NotNull {
+		message := fmt.Sprintf("field %s of type %s cannot hold NULL, column is nullable", col.Name, col.GoType)
+		if def.NotNull {
+			message = fmt.Sprintf("field %s of type %s is nullable, column is NOT NULL", col.Name, col.GoType)
+		}
+		drifts = append(drifts, Drift{
+			Kind:    DriftNullability,
+			Table:   table,
+			Column:  col.ColumnName,
+			Message: message,
+		})
+	}
+	return drifts
+}
+
+// spannerTypesMatch reports whether a model type matches a DDL type
+// A model type without a length, such as STRING, matches any length
+func spannerTypesMatch(modelType, ddlType string) bool {
+	modelType = strings.ToUpper(strings.ReplaceAll(modelType, " ", ""))
+	ddlType = strings.ToUpper(strings.ReplaceAll(ddlType, " ", ""))
+	if !strings.Contains(modelType, "(") {
+		ddlType = lengthPattern.ReplaceAllString(ddlType, "")
+	}
+	return modelType == ddlType
+}
+
+// hasReference reports whether the table references the target column through a
+// single-column foreign key or by being interleaved in the target table
+func (l *ddlLoader) hasReference(t *ddlTable, column, target, targetColumn string) bool {
+	for _, fk := range t.foreignKeys {
+		if len(fk.columns) == 1 &&
+			strings.EqualFold(fk.columns[0], column) &&
+			strings.EqualFold(fk.refTable, target) &&
+			strings.EqualFold(fk.refColumns[0], targetColumn) {
+			return true
+		}
+	}
+
+	parent := l.table(t.schema.Parent)
+	return parent != nil &&
+		strings.EqualFold(parent.schema.TableName, target) &&
+		len(t.schema.PrimaryKey) > 0 && len(parent.schema.PrimaryKey) > 0 &&
+		strings.EqualFold(t.schema.PrimaryKey[0].Column, column) &&
+		strings.EqualFold(parent.schema.PrimaryKey[0].Column, targetColumn)
+}
@@ -0,0 +1,6 @@
+// Package examples exercises the query builders generated from the models in ./models
+// The schema is declared in plate.json; run "go run github.com/rail44/plate/cmd/plate check"
+// to compare the models with schema.sql
+package examples
+
+//go:generate go run github.com/rail44/plate/cmd/plate generate
@@ -11,24 +11,33 @@ import (
 )
 
 // Column accessors for type-safe column references
-func ID() types.Column[tables.Post, string] {
-	return types.Column[tables.Post, string]{Name: "id"}
+func ID() types.StringColumn[tables.Post, string] {
+	return types.StringColumn[tables.Post, string]{Column: types.Column[tables.Post, string]{Name: "id"}}
 }
 
-func UserID() types.Column[tables.Post, string] {
-	return types.Column[tables.Post, string]{Name: "user_id"}
+// ID of the author
+func UserID() types.StringColumn[tables.Post, string] {
+	return types.StringColumn[tables.Post, string]{Column: types.Column[tables.Post, string]{Name: "user_id"}}
 }
 
-func Title() types.Column[tables.Post, string] {
-	return types.Column[tables.Post, string]{Name: "title"}
+func Title() types.StringColumn[tables.Post, string] {
+	return types.StringColumn[tables.Post, string]{Column: types.Column[tables.Post, string]{Name: "title"}}
 }
 
-func Content() types.Column[tables.Post, string] {
-	return types.Column[tables.Post, string]{Name: "content"}
+func Content() types.StringColumn[tables.Post, string] {
+	return types.StringColumn[tables.Post, string]{Column: types.Column[tables.Post, string]{Name: "content"}}
 }
 
-func CreatedAt() types.Column[tables.Post, time.Time] {
-	return types.Column[tables.Post, time.Time]{Name: "created_at"}
+func Views() types.NumericColumn[tables.Post, int64] {
+	return types.NumericColumn[tables.Post, int64]{Column: types.Column[tables.Post, int64]{Name: "views"}}
+}
+
+func Likes() types.NumericColumn[tables.Post, int64] {
+	return types.NumericColumn[tables.Post, int64]{Column: types.Column[tables.Post, int64]{Name: "likes"}}
+}
+
+func CreatedAt() types.TimeColumn[tables.Post, time.Time] {
+	return types.TimeColumn[tables.Post, time.Time]{Column: types.Column[tables.Post, time.Time]{Name: "created_at"}}
 }
 
 // Select creates a SELECT query for the Post table
@@ -36,14 +45,87 @@ func Select(opts ...types.Option[tables.Post]) (string, []any) {
 	return query.Select(opts...)
 }
 
+// Statement creates a SELECT query for the Post table with named parameters
+func Statement(opts ...types.Option[tables.Post]) query.Statement {
+	return query.Build(opts...)
+}
+
+// Insert creates an INSERT statement for the Post table
+func Insert(opts ...types.InsertOption[tables.Post]) (string, []any, error) {
+	return query.Insert(opts...)
+}
+
+// Values adds a row of column values to an INSERT statement
+func Values(values ...types.Assignment[tables.Post]) types.InsertClause[tables.Post] {
+	return query.Values(values...)
+}
+
+// OrUpdate turns an INSERT statement into INSERT OR UPDATE
+func OrUpdate() types.InsertClause[tables.Post] {
+	return query.OrUpdate[tables.Post]()
+}
+
+// OrIgnore turns an INSERT statement into INSERT OR IGNORE
+func OrIgnore() types.InsertClause[tables.Post] {
+	return query.OrIgnore[tables.Post]()
+}
+
+// Update creates an UPDATE statement for the Post table
+// At least one condition is required; pass AllRows() to update every row
+func Update(sets []types.Assignment[tables.Post], opts ...types.UpdateOption[tables.Post]) (string, []any, error) {
+	return query.Update(sets, opts...)
+}
+
+// Delete creates a DELETE statement for the Post table
+// At least one condition is required; pass AllRows() to delete every row
+func Delete(opts ...types.DeleteOption[tables.Post]) (string, []any, error) {
+	return query.Delete(opts...)
+}
+
+// Returning adds a THEN RETURN clause to an INSERT, UPDATE or DELETE statement
+// All columns are returned when none are given
+func Returning(columns ...types.ColumnRef[tables.Post]) types.ReturningOption[tables.Post] {
+	return query.Returning(columns...)
+}
+
 // Limit adds a LIMIT clause to the query
-func Limit(count int) types.QueryOption[tables.Post] {
+func Limit(count int) types.QueryClause[tables.Post] {
 	return query.Limit[tables.Post](count)
 }
 
+// Columns selects only the given columns or expressions instead of all columns
+func Columns(columns ...types.Selectable[tables.Post]) types.QueryOption[tables.Post] {
+	return query.Columns(columns...)
+}
+
+// AddColumns appends columns or expressions to the SELECT list, keeping all columns selected
+func AddColumns(columns ...types.Selectable[tables.Post]) types.QueryOption[tables.Post] {
+	return query.AddColumns(columns...)
+}
+
 // OrderBy adds an ORDER BY clause to the query
-func OrderBy[V any](column types.Column[tables.Post, V], dir ast.Direction) types.QueryOption[tables.Post] {
-	return query.OrderBy(column, dir)
+func OrderBy(expr types.Expression[tables.Post], dir ast.Direction) types.QueryOption[tables.Post] {
+	return query.OrderBy(expr, dir)
+}
+
+// GroupBy adds a GROUP BY clause to the query
+func GroupBy(exprs ...types.Expression[tables.Post]) types.QueryClause[tables.Post] {
+	return query.GroupBy(exprs...)
+}
+
+// Having adds a HAVING clause to the query
+func Having(opts ...types.ExprOption[tables.Post]) types.QueryClause[tables.Post] {
+	return query.Having(opts...)
+}
+
+// CountAll creates a COUNT(*) aggregate
+func CountAll() types.Expr[tables.Post, int64] {
+	return types.CountAll[tables.Post]()
+}
+
+// Param creates an operand for a parameter value, such as a factor in arithmetic
+func Param[V any](value V) types.Expr[tables.Post, V] {
+	return types.Param[tables.Post](value)
 }
 
 // And creates an AND condition that groups multiple conditions
@@ -61,6 +143,17 @@ func Not(opt types.ExprOption[tables.Post]) types.ExprOption[tables.Post] {
 	return query.Not(opt)
 }
 
+// AllRows creates an always-true condition to explicitly target every row
+func AllRows() types.ExprOption[tables.Post] {
+	return query.AllRows[tables.Post]()
+}
+
+// Outer refers to a column of an enclosing query from conditions on Post, such as a column
+// of the parent table inside its With, Where or Join relation to Post
+func Outer[U types.Table, V any](operand types.Operand[U, V]) types.Expr[tables.Post, V] {
+	return types.Outer[tables.Post](operand)
+}
+
 // WithAuthor fetches related User as a nested struct
 func WithAuthor(opts ...types.Option[tables.User]) types.QueryOption[tables.Post] {
 	return query.WithOne[tables.Post, tables.User](
@@ -82,6 +175,32 @@ func WhereAuthor(opts ...types.Option[tables.User]) types.ExprOption[tables.Post
 	)
 }
 
+// JoinAuthor adds an INNER JOIN to the related User, aliased as author
+func JoinAuthor(opts ...types.JoinOption[tables.User]) types.QueryOption[tables.Post] {
+	return query.Join[tables.Post, tables.User](
+		ast.InnerJoin,
+		"author",
+		"user",
+		query.KeyPair{From: "user_id", To: "id"},
+		"", // no junction table
+		query.KeyPair{},
+		opts...,
+	)
+}
+
+// LeftJoinAuthor adds a LEFT OUTER JOIN to the related User, aliased as author
+func LeftJoinAuthor(opts ...types.JoinOption[tables.User]) types.QueryOption[tables.Post] {
+	return query.Join[tables.Post, tables.User](
+		ast.LeftOuterJoin,
+		"author",
+		"user",
+		query.KeyPair{From: "user_id", To: "id"},
+		"", // no junction table
+		query.KeyPair{},
+		opts...,
+	)
+}
+
 // WithTags fetches related Tag through post_tag as a nested array of structs
 func WithTags(opts ...types.Option[tables.Tag]) types.QueryOption[tables.Post] {
 	return query.WithManyThrough[tables.Post, tables.Tag](
@@ -104,3 +223,29 @@ func WhereTags(opts ...types.Option[tables.Tag]) types.ExprOption[tables.Post] {
 		opts...,
 	)
 }
+
+// JoinTags adds an INNER JOIN to the related Tag, aliased as tags
+func JoinTags(opts ...types.JoinOption[tables.Tag]) types.QueryOption[tables.Post] {
+	return query.Join[tables.Post, tables.Tag](
+		ast.InnerJoin,
+		"tags",
+		"tag",
+		query.KeyPair{From: "id", To: "post_id"},
+		"post_tag",
+		query.KeyPair{From: "tag_id", To: "id"},
+		opts...,
+	)
+}
+
+// LeftJoinTags adds a LEFT OUTER JOIN to the related Tag, aliased as tags
+func LeftJoinTags(opts ...types.JoinOption[tables.Tag]) types.QueryOption[tables.Post] {
+	return query.Join[tables.Post, tables.Tag](
+		ast.LeftOuterJoin,
+		"tags",
+		"tag",
+		query.KeyPair{From: "id", To: "post_id"},
+		"post_tag",
+		query.KeyPair{From: "tag_id", To: "id"},
+		opts...,
+	)
+}
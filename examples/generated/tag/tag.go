@@ -10,12 +10,12 @@ import (
 )
 
 // Column accessors for type-safe column references
-func ID() types.Column[tables.Tag, string] {
-	return types.Column[tables.Tag, string]{Name: "id"}
+func ID() types.StringColumn[tables.Tag, string] {
+	return types.StringColumn[tables.Tag, string]{Column: types.Column[tables.Tag, string]{Name: "id"}}
 }
 
-func Name() types.Column[tables.Tag, string] {
-	return types.Column[tables.Tag, string]{Name: "name"}
+func Name() types.StringColumn[tables.Tag, string] {
+	return types.StringColumn[tables.Tag, string]{Column: types.Column[tables.Tag, string]{Name: "name"}}
 }
 
 // Select creates a SELECT query for the Tag table
@@ -23,14 +23,87 @@ func Select(opts ...types.Option[tables.Tag]) (string, []any) {
 	return query.Select(opts...)
 }
 
+// Statement creates a SELECT query for the Tag table with named parameters
+func Statement(opts ...types.Option[tables.Tag]) query.Statement {
+	return query.Build(opts...)
+}
+
+// Insert creates an INSERT statement for the Tag table
+func Insert(opts ...types.InsertOption[tables.Tag]) (string, []any, error) {
+	return query.Insert(opts...)
+}
+
+// Values adds a row of column values to an INSERT statement
+func Values(values ...types.Assignment[tables.Tag]) types.InsertClause[tables.Tag] {
+	return query.Values(values...)
+}
+
+// OrUpdate turns an INSERT statement into INSERT OR UPDATE
+func OrUpdate() types.InsertClause[tables.Tag] {
+	return query.OrUpdate[tables.Tag]()
+}
+
+// OrIgnore turns an INSERT statement into INSERT OR IGNORE
+func OrIgnore() types.InsertClause[tables.Tag] {
+	return query.OrIgnore[tables.Tag]()
+}
+
+// Update creates an UPDATE statement for the Tag table
+// At least one condition is required; pass AllRows() to update every row
+func Update(sets []types.Assignment[tables.Tag], opts ...types.UpdateOption[tables.Tag]) (string, []any, error) {
+	return query.Update(sets, opts...)
+}
+
+// Delete creates a DELETE statement for the Tag table
+// At least one condition is required; pass AllRows() to delete every row
+func Delete(opts ...types.DeleteOption[tables.Tag]) (string, []any, error) {
+	return query.Delete(opts...)
+}
+
+// Returning adds a THEN RETURN clause to an INSERT, UPDATE or DELETE statement
+// All columns are returned when none are given
+func Returning(columns ...types.ColumnRef[tables.Tag]) types.ReturningOption[tables.Tag] {
+	return query.Returning(columns...)
+}
+
 // Limit adds a LIMIT clause to the query
-func Limit(count int) types.QueryOption[tables.Tag] {
+func Limit(count int) types.QueryClause[tables.Tag] {
 	return query.Limit[tables.Tag](count)
 }
 
+// Columns selects only the given columns or expressions instead of all columns
+func Columns(columns ...types.Selectable[tables.Tag]) types.QueryOption[tables.Tag] {
+	return query.Columns(columns...)
+}
+
+// AddColumns appends columns or expressions to the SELECT list, keeping all columns selected
+func AddColumns(columns ...types.Selectable[tables.Tag]) types.QueryOption[tables.Tag] {
+	return query.AddColumns(columns...)
+}
+
 // OrderBy adds an ORDER BY clause to the query
-func OrderBy[V any](column types.Column[tables.Tag, V], dir ast.Direction) types.QueryOption[tables.Tag] {
-	return query.OrderBy(column, dir)
+func OrderBy(expr types.Expression[tables.Tag], dir ast.Direction) types.QueryOption[tables.Tag] {
+	return query.OrderBy(expr, dir)
+}
+
+// GroupBy adds a GROUP BY clause to the query
+func GroupBy(exprs ...types.Expression[tables.Tag]) types.QueryClause[tables.Tag] {
+	return query.GroupBy(exprs...)
+}
+
+// Having adds a HAVING clause to the query
+func Having(opts ...types.ExprOption[tables.Tag]) types.QueryClause[tables.Tag] {
+	return query.Having(opts...)
+}
+
+// CountAll creates a COUNT(*) aggregate
+func CountAll() types.Expr[tables.Tag, int64] {
+	return types.CountAll[tables.Tag]()
+}
+
+// Param creates an operand for a parameter value, such as a factor in arithmetic
+func Param[V any](value V) types.Expr[tables.Tag, V] {
+	return types.Param[tables.Tag](value)
 }
 
 // And creates an AND condition that groups multiple conditions
@@ -48,6 +121,17 @@ func Not(opt types.ExprOption[tables.Tag]) types.ExprOption[tables.Tag] {
 	return query.Not(opt)
 }
 
+// AllRows creates an always-true condition to explicitly target every row
+func AllRows() types.ExprOption[tables.Tag] {
+	return query.AllRows[tables.Tag]()
+}
+
+// Outer refers to a column of an enclosing query from conditions on Tag, such as a column
+// of the parent table inside its With, Where or Join relation to Tag
+func Outer[U types.Table, V any](operand types.Operand[U, V]) types.Expr[tables.Tag, V] {
+	return types.Outer[tables.Tag](operand)
+}
+
 // WithPosts fetches related Post through post_tag as a nested array of structs
 func WithPosts(opts ...types.Option[tables.Post]) types.QueryOption[tables.Tag] {
 	return query.WithManyThrough[tables.Tag, tables.Post](
@@ -70,3 +154,29 @@ func WherePosts(opts ...types.Option[tables.Post]) types.ExprOption[tables.Tag]
 		opts...,
 	)
 }
+
+// JoinPosts adds an INNER JOIN to the related Post, aliased as posts
+func JoinPosts(opts ...types.JoinOption[tables.Post]) types.QueryOption[tables.Tag] {
+	return query.Join[tables.Tag, tables.Post](
+		ast.InnerJoin,
+		"posts",
+		"post",
+		query.KeyPair{From: "id", To: "tag_id"},
+		"post_tag",
+		query.KeyPair{From: "post_id", To: "id"},
+		opts...,
+	)
+}
+
+// LeftJoinPosts adds a LEFT OUTER JOIN to the related Post, aliased as posts
+func LeftJoinPosts(opts ...types.JoinOption[tables.Post]) types.QueryOption[tables.Tag] {
+	return query.Join[tables.Tag, tables.Post](
+		ast.LeftOuterJoin,
+		"posts",
+		"post",
+		query.KeyPair{From: "id", To: "tag_id"},
+		"post_tag",
+		query.KeyPair{From: "post_id", To: "id"},
+		opts...,
+	)
+}
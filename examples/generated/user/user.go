@@ -11,20 +11,25 @@ import (
 )
 
 // Column accessors for type-safe column references
-func ID() types.Column[tables.User, string] {
-	return types.Column[tables.User, string]{Name: "id"}
+func ID() types.StringColumn[tables.User, string] {
+	return types.StringColumn[tables.User, string]{Column: types.Column[tables.User, string]{Name: "id"}}
 }
 
-func Name() types.Column[tables.User, string] {
-	return types.Column[tables.User, string]{Name: "name"}
+func Name() types.StringColumn[tables.User, string] {
+	return types.StringColumn[tables.User, string]{Column: types.Column[tables.User, string]{Name: "name"}}
 }
 
-func Email() types.Column[tables.User, string] {
-	return types.Column[tables.User, string]{Name: "email"}
+func Email() types.StringColumn[tables.User, string] {
+	return types.StringColumn[tables.User, string]{Column: types.Column[tables.User, string]{Name: "email"}}
 }
 
-func CreatedAt() types.Column[tables.User, time.Time] {
-	return types.Column[tables.User, time.Time]{Name: "created_at"}
+// ID of the user's manager, NULL for top-level users
+func ManagerID() types.StringColumn[tables.User, *string] {
+	return types.StringColumn[tables.User, *string]{Column: types.Column[tables.User, *string]{Name: "manager_id"}}
+}
+
+func CreatedAt() types.TimeColumn[tables.User, time.Time] {
+	return types.TimeColumn[tables.User, time.Time]{Column: types.Column[tables.User, time.Time]{Name: "created_at"}}
 }
 
 // Select creates a SELECT query for the User table
@@ -32,14 +37,87 @@ func Select(opts ...types.Option[tables.User]) (string, []any) {
 	return query.Select(opts...)
 }
 
+// Statement creates a SELECT query for the User table with named parameters
+func Statement(opts ...types.Option[tables.User]) query.Statement {
+	return query.Build(opts...)
+}
+
+// Insert creates an INSERT statement for the User table
+func Insert(opts ...types.InsertOption[tables.User]) (string, []any, error) {
+	return query.Insert(opts...)
+}
+
+// Values adds a row of column values to an INSERT statement
+func Values(values ...types.Assignment[tables.User]) types.InsertClause[tables.User] {
+	return query.Values(values...)
+}
+
+// OrUpdate turns an INSERT statement into INSERT OR UPDATE
+func OrUpdate() types.InsertClause[tables.User] {
+	return query.OrUpdate[tables.User]()
+}
+
+// OrIgnore turns an INSERT statement into INSERT OR IGNORE
+func OrIgnore() types.InsertClause[tables.User] {
+	return query.OrIgnore[tables.User]()
+}
+
+// Update creates an UPDATE statement for the User table
+// At least one condition is required; pass AllRows() to update every row
+func Update(sets []types.Assignment[tables.User], opts ...types.UpdateOption[tables.User]) (string, []any, error) {
+	return query.Update(sets, opts...)
+}
+
+// Delete creates a DELETE statement for the User table
+// At least one condition is required; pass AllRows() to delete every row
+func Delete(opts ...types.DeleteOption[tables.User]) (string, []any, error) {
+	return query.Delete(opts...)
+}
+
+// Returning adds a THEN RETURN clause to an INSERT, UPDATE or DELETE statement
+// All columns are returned when none are given
+func Returning(columns ...types.ColumnRef[tables.User]) types.ReturningOption[tables.User] {
+	return query.Returning(columns...)
+}
+
 // Limit adds a LIMIT clause to the query
-func Limit(count int) types.QueryOption[tables.User] {
+func Limit(count int) types.QueryClause[tables.User] {
 	return query.Limit[tables.User](count)
 }
 
+// Columns selects only the given columns or expressions instead of all columns
+func Columns(columns ...types.Selectable[tables.User]) types.QueryOption[tables.User] {
+	return query.Columns(columns...)
+}
+
+// AddColumns appends columns or expressions to the SELECT list, keeping all columns selected
+func AddColumns(columns ...types.Selectable[tables.User]) types.QueryOption[tables.User] {
+	return query.AddColumns(columns...)
+}
+
 // OrderBy adds an ORDER BY clause to the query
-func OrderBy[V any](column types.Column[tables.User, V], dir ast.Direction) types.QueryOption[tables.User] {
-	return query.OrderBy(column, dir)
+func OrderBy(expr types.Expression[tables.User], dir ast.Direction) types.QueryOption[tables.User] {
+	return query.OrderBy(expr, dir)
+}
+
+// GroupBy adds a GROUP BY clause to the query
+func GroupBy(exprs ...types.Expression[tables.User]) types.QueryClause[tables.User] {
+	return query.GroupBy(exprs...)
+}
+
+// Having adds a HAVING clause to the query
+func Having(opts ...types.ExprOption[tables.User]) types.QueryClause[tables.User] {
+	return query.Having(opts...)
+}
+
+// CountAll creates a COUNT(*) aggregate
+func CountAll() types.Expr[tables.User, int64] {
+	return types.CountAll[tables.User]()
+}
+
+// Param creates an operand for a parameter value, such as a factor in arithmetic
+func Param[V any](value V) types.Expr[tables.User, V] {
+	return types.Param[tables.User](value)
 }
 
 // And creates an AND condition that groups multiple conditions
@@ -57,6 +135,111 @@ func Not(opt types.ExprOption[tables.User]) types.ExprOption[tables.User] {
 	return query.Not(opt)
 }
 
+// AllRows creates an always-true condition to explicitly target every row
+func AllRows() types.ExprOption[tables.User] {
+	return query.AllRows[tables.User]()
+}
+
+// Outer refers to a column of an enclosing query from conditions on User, such as a column
+// of the parent table inside its With, Where or Join relation to User
+func Outer[U types.Table, V any](operand types.Operand[U, V]) types.Expr[tables.User, V] {
+	return types.Outer[tables.User](operand)
+}
+
+// WithManager fetches related User as a nested struct
+func WithManager(opts ...types.Option[tables.User]) types.QueryOption[tables.User] {
+	return query.WithOne[tables.User, tables.User](
+		"manager",
+		"user",
+		query.KeyPair{From: "manager_id", To: "id"},
+		opts...,
+	)
+}
+
+// WhereManager filters User by conditions on its Manager
+func WhereManager(opts ...types.Option[tables.User]) types.ExprOption[tables.User] {
+	return query.WhereExists[tables.User, tables.User](
+		"user",
+		query.KeyPair{From: "manager_id", To: "id"},
+		"", // no junction table
+		query.KeyPair{},
+		opts...,
+	)
+}
+
+// JoinManager adds an INNER JOIN to the related User, aliased as manager
+func JoinManager(opts ...types.JoinOption[tables.User]) types.QueryOption[tables.User] {
+	return query.Join[tables.User, tables.User](
+		ast.InnerJoin,
+		"manager",
+		"user",
+		query.KeyPair{From: "manager_id", To: "id"},
+		"", // no junction table
+		query.KeyPair{},
+		opts...,
+	)
+}
+
+// LeftJoinManager adds a LEFT OUTER JOIN to the related User, aliased as manager
+func LeftJoinManager(opts ...types.JoinOption[tables.User]) types.QueryOption[tables.User] {
+	return query.Join[tables.User, tables.User](
+		ast.LeftOuterJoin,
+		"manager",
+		"user",
+		query.KeyPair{From: "manager_id", To: "id"},
+		"", // no junction table
+		query.KeyPair{},
+		opts...,
+	)
+}
+
+// WithReports fetches related User as a nested array of structs
+func WithReports(opts ...types.Option[tables.User]) types.QueryOption[tables.User] {
+	return query.WithMany[tables.User, tables.User](
+		"reports",
+		"user",
+		query.KeyPair{From: "id", To: "manager_id"},
+		opts...,
+	)
+}
+
+// WhereReports filters User by conditions on its Reports
+func WhereReports(opts ...types.Option[tables.User]) types.ExprOption[tables.User] {
+	return query.WhereExists[tables.User, tables.User](
+		"user",
+		query.KeyPair{From: "id", To: "manager_id"},
+		"", // no junction table
+		query.KeyPair{},
+		opts...,
+	)
+}
+
+// JoinReports adds an INNER JOIN to the related User, aliased as reports
+func JoinReports(opts ...types.JoinOption[tables.User]) types.QueryOption[tables.User] {
+	return query.Join[tables.User, tables.User](
+		ast.InnerJoin,
+		"reports",
+		"user",
+		query.KeyPair{From: "id", To: "manager_id"},
+		"", // no junction table
+		query.KeyPair{},
+		opts...,
+	)
+}
+
+// LeftJoinReports adds a LEFT OUTER JOIN to the related User, aliased as reports
+func LeftJoinReports(opts ...types.JoinOption[tables.User]) types.QueryOption[tables.User] {
+	return query.Join[tables.User, tables.User](
+		ast.LeftOuterJoin,
+		"reports",
+		"user",
+		query.KeyPair{From: "id", To: "manager_id"},
+		"", // no junction table
+		query.KeyPair{},
+		opts...,
+	)
+}
+
 // WithPosts fetches related Post as a nested array of structs
 func WithPosts(opts ...types.Option[tables.Post]) types.QueryOption[tables.User] {
 	return query.WithMany[tables.User, tables.Post](
@@ -77,3 +260,29 @@ func WherePosts(opts ...types.Option[tables.Post]) types.ExprOption[tables.User]
 		opts...,
 	)
 }
+
+// JoinPosts adds an INNER JOIN to the related Post, aliased as posts
+func JoinPosts(opts ...types.JoinOption[tables.Post]) types.QueryOption[tables.User] {
+	return query.Join[tables.User, tables.Post](
+		ast.InnerJoin,
+		"posts",
+		"post",
+		query.KeyPair{From: "id", To: "user_id"},
+		"", // no junction table
+		query.KeyPair{},
+		opts...,
+	)
+}
+
+// LeftJoinPosts adds a LEFT OUTER JOIN to the related Post, aliased as posts
+func LeftJoinPosts(opts ...types.JoinOption[tables.Post]) types.QueryOption[tables.User] {
+	return query.Join[tables.User, tables.Post](
+		ast.LeftOuterJoin,
+		"posts",
+		"post",
+		query.KeyPair{From: "id", To: "user_id"},
+		"", // no junction table
+		query.KeyPair{},
+		opts...,
+	)
+}
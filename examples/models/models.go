@@ -7,15 +7,18 @@ type User struct {
 	ID        string    `spanner:"id" spannerType:"STRING"`
 	Name      string    `spanner:"name" spannerType:"STRING"`
 	Email     string    `spanner:"email" spannerType:"STRING"`
+	ManagerID *string   `spanner:"manager_id" spannerType:"STRING" plate:"belongs_to=User,reverse=Reports"` // ID of the user's manager, NULL for top-level users
 	CreatedAt time.Time `spanner:"created_at" spannerType:"TIMESTAMP"`
 }
 
 // Post represents a blog post
 type Post struct {
 	ID        string    `spanner:"id" spannerType:"STRING"`
-	UserID    string    `spanner:"user_id" spannerType:"STRING"`
+	UserID    string    `spanner:"user_id" spannerType:"STRING" plate:"belongs_to=User,name=Author,reverse=Posts"` // ID of the author
 	Title     string    `spanner:"title" spannerType:"STRING"`
 	Content   string    `spanner:"content" spannerType:"STRING"`
+	Views     int64     `spanner:"views" spannerType:"INT64"`
+	Likes     int64     `spanner:"likes" spannerType:"INT64"`
 	CreatedAt time.Time `spanner:"created_at" spannerType:"TIMESTAMP"`
 }
 
@@ -27,7 +30,8 @@ type Tag struct {
 
 // PostTag represents the junction table for post-tag relationships
 type PostTag struct {
-	PostID    string    `spanner:"post_id" spannerType:"STRING"`
-	TagID     string    `spanner:"tag_id" spannerType:"STRING"`
+	_         struct{}  `plate:"junction"`
+	PostID    string    `spanner:"post_id" spannerType:"STRING" plate:"belongs_to=Post,reverse=Tags"`
+	TagID     string    `spanner:"tag_id" spannerType:"STRING" plate:"belongs_to=Tag,reverse=Posts"`
 	CreatedAt time.Time `spanner:"created_at" spannerType:"TIMESTAMP"`
 }
@@ -1,13 +1,17 @@
-package main
+package examples
 
 import (
+	"math/big"
 	"testing"
 	"time"
 
 	"github.com/cloudspannerecosystem/memefish/ast"
 	"github.com/rail44/plate/examples/generated/post"
+	"github.com/rail44/plate/examples/generated/tables"
 	"github.com/rail44/plate/examples/generated/tag"
 	"github.com/rail44/plate/examples/generated/user"
+	"github.com/rail44/plate/query"
+	"github.com/rail44/plate/types"
 )
 
 func TestUserQueries(t *testing.T) {
@@ -67,6 +71,38 @@ func TestUserQueries(t *testing.T) {
 			wantSQL:  "SELECT user.*, ARRAY(SELECT AS STRUCT * FROM post WHERE post.user_id = user.id AND post.title = @p0) AS posts FROM user",
 			wantArgs: []any{"Hello World"},
 		},
+		{
+			name: "select with column projection",
+			query: func() (string, []any) {
+				return user.Select(
+					user.Columns(user.ID(), user.Name()),
+					user.Email().Eq("john@example.com"),
+				)
+			},
+			wantSQL:  "SELECT user.id, user.name FROM user WHERE user.email = @p0",
+			wantArgs: []any{"john@example.com"},
+		},
+		{
+			name: "select with empty column projection keeps star",
+			query: func() (string, []any) {
+				return user.Select(
+					user.Columns(),
+				)
+			},
+			wantSQL:  "SELECT user.* FROM user",
+			wantArgs: nil,
+		},
+		{
+			name: "select with projected has_many subquery",
+			query: func() (string, []any) {
+				return user.Select(
+					user.Columns(user.ID()),
+					user.WithPosts(post.Columns(post.ID(), post.Title())),
+				)
+			},
+			wantSQL:  "SELECT user.id, ARRAY(SELECT AS STRUCT post.id, post.title FROM post WHERE post.user_id = user.id) AS posts FROM user",
+			wantArgs: nil,
+		},
 		{
 			name: "select filtered by has_many",
 			query: func() (string, []any) {
@@ -131,6 +167,26 @@ func TestPostQueries(t *testing.T) {
 			wantSQL:  "SELECT post.*, ARRAY(SELECT AS STRUCT tag.* FROM tag INNER JOIN post_tag ON tag.id = post_tag.tag_id WHERE post_tag.post_id = post.id AND tag.name = @p0) AS tags FROM post",
 			wantArgs: []any{"golang"},
 		},
+		{
+			name: "select with projected belongs_to subquery",
+			query: func() (string, []any) {
+				return post.Select(
+					post.WithAuthor(user.Columns(user.Name())),
+				)
+			},
+			wantSQL:  "SELECT post.*, (SELECT AS STRUCT user.name FROM user WHERE user.id = post.user_id) AS author FROM post",
+			wantArgs: nil,
+		},
+		{
+			name: "select with projected many_to_many subquery",
+			query: func() (string, []any) {
+				return post.Select(
+					post.WithTags(tag.Columns(tag.Name())),
+				)
+			},
+			wantSQL:  "SELECT post.*, ARRAY(SELECT AS STRUCT tag.name FROM tag INNER JOIN post_tag ON tag.id = post_tag.tag_id WHERE post_tag.post_id = post.id) AS tags FROM post",
+			wantArgs: nil,
+		},
 		{
 			name: "complex query with multiple conditions",
 			query: func() (string, []any) {
@@ -293,3 +349,1272 @@ func TestLogicalOperators(t *testing.T) {
 		})
 	}
 }
+
+func TestInsertQueries(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    func() (string, []any, error)
+		wantSQL  string
+		wantArgs []any
+		wantErr  bool
+	}{
+		{
+			name: "single row insert",
+			query: func() (string, []any, error) {
+				return user.Insert(
+					user.Values(
+						user.ID().Set("u1"),
+						user.Name().Set("Alice"),
+					),
+				)
+			},
+			wantSQL:  "INSERT INTO user (id, name) VALUES (@p0, @p1)",
+			wantArgs: []any{"u1", "Alice"},
+		},
+		{
+			name: "multi row insert",
+			query: func() (string, []any, error) {
+				return tag.Insert(
+					tag.Values(tag.ID().Set("t1"), tag.Name().Set("go")),
+					tag.Values(tag.ID().Set("t2"), tag.Name().Set("sql")),
+				)
+			},
+			wantSQL:  "INSERT INTO tag (id, name) VALUES (@p0, @p1), (@p2, @p3)",
+			wantArgs: []any{"t1", "go", "t2", "sql"},
+		},
+		{
+			name: "rows with different columns are filled with DEFAULT",
+			query: func() (string, []any, error) {
+				return user.Insert(
+					user.Values(user.ID().Set("u1"), user.Name().Set("Alice")),
+					user.Values(user.Email().Set("bob@example.com"), user.ID().Set("u2")),
+				)
+			},
+			wantSQL:  "INSERT INTO user (id, name, email) VALUES (@p0, @p1, DEFAULT), (@p3, DEFAULT, @p2)",
+			wantArgs: []any{"u1", "Alice", "bob@example.com", "u2"},
+		},
+		{
+			name: "insert or update",
+			query: func() (string, []any, error) {
+				return tag.Insert(
+					tag.Values(tag.ID().Set("t1"), tag.Name().Set("go")),
+					tag.OrUpdate(),
+				)
+			},
+			wantSQL:  "INSERT OR UPDATE INTO tag (id, name) VALUES (@p0, @p1)",
+			wantArgs: []any{"t1", "go"},
+		},
+		{
+			name: "multi row insert or update with the same columns",
+			query: func() (string, []any, error) {
+				return tag.Insert(
+					tag.OrUpdate(),
+					tag.Values(tag.ID().Set("t1"), tag.Name().Set("go")),
+					tag.Values(tag.Name().Set("sql"), tag.ID().Set("t2")),
+				)
+			},
+			wantSQL:  "INSERT OR UPDATE INTO tag (id, name) VALUES (@p0, @p1), (@p3, @p2)",
+			wantArgs: []any{"t1", "go", "sql", "t2"},
+		},
+		{
+			name: "insert or update with rows setting different columns",
+			query: func() (string, []any, error) {
+				return user.Insert(
+					user.OrUpdate(),
+					user.Values(user.ID().Set("u1"), user.Name().Set("Alice")),
+					user.Values(user.ID().Set("u2"), user.Email().Set("bob@example.com")),
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name: "insert or ignore",
+			query: func() (string, []any, error) {
+				return tag.Insert(
+					tag.OrIgnore(),
+					tag.Values(tag.ID().Set("t1")),
+				)
+			},
+			wantSQL:  "INSERT OR IGNORE INTO tag (id) VALUES (@p0)",
+			wantArgs: []any{"t1"},
+		},
+		{
+			name: "insert returning all columns",
+			query: func() (string, []any, error) {
+				return user.Insert(
+					user.Values(user.ID().Set("u1"), user.Name().Set("Alice")),
+					user.Returning(),
+				)
+			},
+			wantSQL:  "INSERT INTO user (id, name) VALUES (@p0, @p1) THEN RETURN *",
+			wantArgs: []any{"u1", "Alice"},
+		},
+		{
+			name: "insert without values",
+			query: func() (string, []any, error) {
+				return user.Insert()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.query()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got SQL: %s", sql)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("SQL mismatch\ngot:  %s\nwant: %s", sql, tt.wantSQL)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Errorf("Args length mismatch\ngot:  %d\nwant: %d", len(args), len(tt.wantArgs))
+			} else {
+				for i, arg := range args {
+					if arg != tt.wantArgs[i] {
+						t.Errorf("Arg[%d] mismatch\ngot:  %v\nwant: %v", i, arg, tt.wantArgs[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateQueries(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    func() (string, []any, error)
+		wantSQL  string
+		wantArgs []any
+		wantErr  bool
+	}{
+		{
+			name: "update with condition",
+			query: func() (string, []any, error) {
+				return post.Update(
+					[]types.Assignment[tables.Post]{
+						post.Title().Set("New Title"),
+						post.Content().Set("Updated"),
+					},
+					post.ID().Eq("p1"),
+				)
+			},
+			wantSQL:  "UPDATE post SET title = @p0, content = @p1 WHERE post.id = @p2",
+			wantArgs: []any{"New Title", "Updated", "p1"},
+		},
+		{
+			name: "update filtered by belongs_to",
+			query: func() (string, []any, error) {
+				return post.Update(
+					[]types.Assignment[tables.Post]{post.Title().Set("Hidden")},
+					post.Title().Like("%draft%"),
+					post.WhereAuthor(user.Name().Eq("Alice")),
+				)
+			},
+			wantSQL:  "UPDATE post SET title = @p0 WHERE post.title LIKE @p1 AND EXISTS(SELECT 1 FROM user WHERE user.id = post.user_id AND user.name = @p2)",
+			wantArgs: []any{"Hidden", "%draft%", "Alice"},
+		},
+		{
+			name: "update all rows requires explicit opt-in",
+			query: func() (string, []any, error) {
+				return post.Update(
+					[]types.Assignment[tables.Post]{post.Content().Set("")},
+					post.AllRows(),
+				)
+			},
+			wantSQL:  "UPDATE post SET content = @p0 WHERE TRUE",
+			wantArgs: []any{""},
+		},
+		{
+			name: "update returning columns",
+			query: func() (string, []any, error) {
+				return post.Update(
+					[]types.Assignment[tables.Post]{post.Title().Set("New Title")},
+					post.ID().Eq("p1"),
+					post.Returning(post.ID(), post.Title(), post.CreatedAt()),
+				)
+			},
+			wantSQL:  "UPDATE post SET title = @p0 WHERE post.id = @p1 THEN RETURN id, title, created_at",
+			wantArgs: []any{"New Title", "p1"},
+		},
+		{
+			name: "update with repeated returning",
+			query: func() (string, []any, error) {
+				return post.Update(
+					[]types.Assignment[tables.Post]{post.Title().Set("New Title")},
+					post.ID().Eq("p1"),
+					post.Returning(post.ID()),
+					post.Returning(post.Title()),
+				)
+			},
+			wantSQL:  "UPDATE post SET title = @p0 WHERE post.id = @p1 THEN RETURN id, title",
+			wantArgs: []any{"New Title", "p1"},
+		},
+		{
+			name: "update without condition",
+			query: func() (string, []any, error) {
+				return post.Update(
+					[]types.Assignment[tables.Post]{post.Content().Set("")},
+				)
+			},
+			wantErr: true,
+		},
+		{
+			name: "update without assignments",
+			query: func() (string, []any, error) {
+				return post.Update(nil, post.AllRows())
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.query()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got SQL: %s", sql)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("SQL mismatch\ngot:  %s\nwant: %s", sql, tt.wantSQL)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Errorf("Args length mismatch\ngot:  %d\nwant: %d", len(args), len(tt.wantArgs))
+			} else {
+				for i, arg := range args {
+					if arg != tt.wantArgs[i] {
+						t.Errorf("Arg[%d] mismatch\ngot:  %v\nwant: %v", i, arg, tt.wantArgs[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDeleteQueries(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    func() (string, []any, error)
+		wantSQL  string
+		wantArgs []any
+		wantErr  bool
+	}{
+		{
+			name: "delete with condition",
+			query: func() (string, []any, error) {
+				return user.Delete(
+					user.Email().Like("%@spam.com"),
+				)
+			},
+			wantSQL:  "DELETE FROM user WHERE user.email LIKE @p0",
+			wantArgs: []any{"%@spam.com"},
+		},
+		{
+			name: "delete filtered by many_to_many",
+			query: func() (string, []any, error) {
+				return tag.Delete(
+					tag.Name().Ne("pinned"),
+					tag.WherePosts(post.Title().Eq("Removed")),
+				)
+			},
+			wantSQL:  "DELETE FROM tag WHERE tag.name != @p0 AND EXISTS(SELECT 1 FROM post INNER JOIN post_tag ON post.id = post_tag.post_id WHERE post_tag.tag_id = tag.id AND post.title = @p1)",
+			wantArgs: []any{"pinned", "Removed"},
+		},
+		{
+			name: "delete orphaned rows",
+			query: func() (string, []any, error) {
+				return tag.Delete(
+					tag.Not(tag.WherePosts()),
+				)
+			},
+			wantSQL:  "DELETE FROM tag WHERE NOT (EXISTS(SELECT 1 FROM post INNER JOIN post_tag ON post.id = post_tag.post_id WHERE post_tag.tag_id = tag.id))",
+			wantArgs: nil,
+		},
+		{
+			name: "delete all rows requires explicit opt-in",
+			query: func() (string, []any, error) {
+				return tag.Delete(tag.AllRows())
+			},
+			wantSQL:  "DELETE FROM tag WHERE TRUE",
+			wantArgs: nil,
+		},
+		{
+			name: "delete returning columns",
+			query: func() (string, []any, error) {
+				return tag.Delete(
+					tag.Returning(tag.ID()),
+					tag.Name().Eq("obsolete"),
+				)
+			},
+			wantSQL:  "DELETE FROM tag WHERE tag.name = @p0 THEN RETURN id",
+			wantArgs: []any{"obsolete"},
+		},
+		{
+			name: "delete without condition",
+			query: func() (string, []any, error) {
+				return tag.Delete()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args, err := tt.query()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got SQL: %s", sql)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if sql != tt.wantSQL {
+				t.Errorf("SQL mismatch\ngot:  %s\nwant: %s", sql, tt.wantSQL)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Errorf("Args length mismatch\ngot:  %d\nwant: %d", len(args), len(tt.wantArgs))
+			} else {
+				for i, arg := range args {
+					if arg != tt.wantArgs[i] {
+						t.Errorf("Arg[%d] mismatch\ngot:  %v\nwant: %v", i, arg, tt.wantArgs[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestStatement(t *testing.T) {
+	stmt := user.Statement(
+		user.Name().Eq("Alice"),
+		user.WherePosts(post.Title().Like("%go%")),
+		user.Limit(1),
+	)
+
+	wantSQL := "SELECT user.* FROM user WHERE user.name = @p0 AND EXISTS(SELECT 1 FROM post WHERE post.user_id = user.id AND post.title LIKE @p1) LIMIT 1"
+	if stmt.SQL != wantSQL {
+		t.Errorf("SQL mismatch\ngot:  %s\nwant: %s", stmt.SQL, wantSQL)
+	}
+
+	wantParams := map[string]any{"p0": "Alice", "p1": "%go%"}
+	if len(stmt.Params) != len(wantParams) {
+		t.Errorf("Params length mismatch\ngot:  %d\nwant: %d", len(stmt.Params), len(wantParams))
+	}
+	for name, want := range wantParams {
+		if got := stmt.Params[name]; got != want {
+			t.Errorf("Param %s mismatch\ngot:  %v\nwant: %v", name, got, want)
+		}
+	}
+
+	if stmt.Query == nil || stmt.Query.SQL() != stmt.SQL {
+		t.Errorf("Query does not match SQL: %v", stmt.Query)
+	}
+}
+
+func TestAggregateQueries(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    func() (string, []any)
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name: "count with group by and having",
+			query: func() (string, []any) {
+				return post.Select(
+					post.Columns(post.UserID(), post.CountAll()),
+					post.GroupBy(post.UserID()),
+					post.Having(post.CountAll().Gt(5)),
+				)
+			},
+			wantSQL:  "SELECT post.user_id, COUNT(*) FROM post GROUP BY post.user_id HAVING COUNT(*) > @p0",
+			wantArgs: []any{int64(5)},
+		},
+		{
+			name: "aliased aggregates sharing parameter numbering with WHERE",
+			query: func() (string, []any) {
+				testTime := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+				return post.Select(
+					post.Columns(
+						post.UserID(),
+						post.CountAll().As("post_count"),
+						post.CreatedAt().Max().As("latest"),
+					),
+					post.Title().Like("%go%"),
+					post.GroupBy(post.UserID()),
+					post.Having(
+						post.CreatedAt().Min().Ge(testTime),
+						post.ID().Count().Le(100),
+					),
+					post.OrderBy(post.CountAll(), ast.DirectionDesc),
+				)
+			},
+			wantSQL:  "SELECT post.user_id, COUNT(*) AS post_count, MAX(post.created_at) AS latest FROM post WHERE post.title LIKE @p0 GROUP BY post.user_id HAVING MIN(post.created_at) >= @p1 AND COUNT(post.id) <= @p2 ORDER BY COUNT(*) DESC",
+			wantArgs: []any{"%go%", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), int64(100)},
+		},
+		{
+			name: "array and string aggregates",
+			query: func() (string, []any) {
+				return post.Select(
+					post.Columns(
+						post.UserID(),
+						post.ID().ArrayAgg().As("post_ids"),
+						post.Title().StringAgg(", ").As("titles"),
+					),
+					post.GroupBy(post.UserID()),
+				)
+			},
+			wantSQL:  `SELECT post.user_id, ARRAY_AGG(post.id) AS post_ids, STRING_AGG(post.title, ", ") AS titles FROM post GROUP BY post.user_id`,
+			wantArgs: nil,
+		},
+		{
+			name: "group by and having inside has_many subquery",
+			query: func() (string, []any) {
+				return user.Select(
+					user.WithPosts(
+						post.Columns(post.Title(), post.CountAll().As("n")),
+						post.GroupBy(post.Title()),
+						post.Having(post.CountAll().Gt(1)),
+					),
+				)
+			},
+			wantSQL:  "SELECT user.*, ARRAY(SELECT AS STRUCT post.title, COUNT(*) AS n FROM post WHERE post.user_id = user.id GROUP BY post.title HAVING COUNT(*) > @p0) AS posts FROM user",
+			wantArgs: []any{int64(1)},
+		},
+		{
+			name: "group by inside many_to_many and belongs_to subqueries",
+			query: func() (string, []any) {
+				return post.Select(
+					post.WithTags(
+						tag.Columns(tag.Name()),
+						tag.GroupBy(tag.Name()),
+					),
+					post.WithAuthor(
+						user.Columns(user.Name()),
+						user.GroupBy(user.Name()),
+					),
+				)
+			},
+			wantSQL:  "SELECT post.*, ARRAY(SELECT AS STRUCT tag.name FROM tag INNER JOIN post_tag ON tag.id = post_tag.tag_id WHERE post_tag.post_id = post.id GROUP BY tag.name) AS tags, (SELECT AS STRUCT user.name FROM user WHERE user.id = post.user_id GROUP BY user.name) AS author FROM post",
+			wantArgs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.query()
+			if sql != tt.wantSQL {
+				t.Errorf("SQL mismatch\ngot:  %s\nwant: %s", sql, tt.wantSQL)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Errorf("Args length mismatch\ngot:  %d\nwant: %d", len(args), len(tt.wantArgs))
+			} else {
+				for i, arg := range args {
+					if arg != tt.wantArgs[i] {
+						t.Errorf("Arg[%d] mismatch\ngot:  %v\nwant: %v", i, arg, tt.wantArgs[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestJoinQueries(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    func() (string, []any)
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name: "inner join belongs_to with condition",
+			query: func() (string, []any) {
+				return post.Select(
+					post.JoinAuthor(user.Name().Eq("Alice")),
+				)
+			},
+			wantSQL:  "SELECT post.* FROM post INNER JOIN user AS author ON author.id = post.user_id AND author.name = @p0",
+			wantArgs: []any{"Alice"},
+		},
+		{
+			name: "left join has_many projecting joined columns",
+			query: func() (string, []any) {
+				return user.Select(
+					user.Columns(user.ID(), user.Name()),
+					user.LeftJoinPosts(post.Columns(post.Title())),
+					user.Email().Like("%@example.com"),
+				)
+			},
+			wantSQL:  "SELECT user.id, user.name, posts.title FROM user LEFT OUTER JOIN post AS posts ON posts.user_id = user.id WHERE user.email LIKE @p0",
+			wantArgs: []any{"%@example.com"},
+		},
+		{
+			name: "join many_to_many through junction",
+			query: func() (string, []any) {
+				return post.Select(
+					post.JoinTags(
+						tag.Name().Eq("go"),
+						tag.OrderBy(tag.Name(), ast.DirectionAsc),
+					),
+					post.Limit(10),
+				)
+			},
+			wantSQL:  "SELECT post.* FROM post INNER JOIN post_tag ON post_tag.post_id = post.id INNER JOIN tag AS tags ON tags.id = post_tag.tag_id AND tags.name = @p0 ORDER BY tags.name ASC LIMIT 10",
+			wantArgs: []any{"go"},
+		},
+		{
+			name: "join with parameters numbered in order",
+			query: func() (string, []any) {
+				return post.Select(
+					post.Title().Eq("Hello"),
+					post.JoinAuthor(user.Email().Eq("a@example.com")),
+					post.Content().Ne(""),
+				)
+			},
+			wantSQL:  "SELECT post.* FROM post INNER JOIN user AS author ON author.id = post.user_id AND author.email = @p1 WHERE post.title = @p0 AND post.content != @p2",
+			wantArgs: []any{"Hello", "a@example.com", ""},
+		},
+		{
+			name: "join nested in a join",
+			query: func() (string, []any) {
+				return user.Select(
+					user.JoinPosts(post.JoinTags(tag.Columns(tag.Name()))),
+				)
+			},
+			wantSQL:  "SELECT user.*, tags.name FROM user INNER JOIN (post AS posts INNER JOIN post_tag ON post_tag.post_id = posts.id INNER JOIN tag AS tags ON tags.id = post_tag.tag_id) ON posts.user_id = user.id",
+			wantArgs: nil,
+		},
+		{
+			name: "join inside has_many subquery",
+			query: func() (string, []any) {
+				return user.Select(
+					user.WithPosts(post.JoinTags(tag.Name().Eq("x"))),
+				)
+			},
+			wantSQL:  "SELECT user.*, ARRAY(SELECT AS STRUCT post.* FROM post INNER JOIN post_tag ON post_tag.post_id = post.id INNER JOIN tag AS tags ON tags.id = post_tag.tag_id AND tags.name = @p0 WHERE post.user_id = user.id) AS posts FROM user",
+			wantArgs: []any{"x"},
+		},
+		{
+			name: "join inside many_to_many subquery",
+			query: func() (string, []any) {
+				return post.Select(
+					post.WithTags(tag.JoinPosts(post.Title().Eq("x"))),
+				)
+			},
+			wantSQL:  "SELECT post.*, ARRAY(SELECT AS STRUCT tag.* FROM tag INNER JOIN post_tag AS post_tag_1 ON post_tag_1.tag_id = tag.id INNER JOIN post AS posts ON posts.id = post_tag_1.post_id AND posts.title = @p0 INNER JOIN post_tag ON tag.id = post_tag.tag_id WHERE post_tag.post_id = post.id) AS tags FROM post",
+			wantArgs: []any{"x"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.query()
+			if sql != tt.wantSQL {
+				t.Errorf("SQL mismatch\ngot:  %s\nwant: %s", sql, tt.wantSQL)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Errorf("Args length mismatch\ngot:  %d\nwant: %d", len(args), len(tt.wantArgs))
+			} else {
+				for i, arg := range args {
+					if arg != tt.wantArgs[i] {
+						t.Errorf("Arg[%d] mismatch\ngot:  %v\nwant: %v", i, arg, tt.wantArgs[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestJoinRejectsQueryClauses(t *testing.T) {
+	clauses := map[string]types.Option[tables.User]{
+		"limit":    user.Limit(1),
+		"group by": user.GroupBy(user.ID()),
+		"having":   user.Having(user.CountAll().Gt(1)),
+	}
+	for name, clause := range clauses {
+		if _, ok := clause.(types.JoinOption[tables.User]); ok {
+			t.Errorf("%s must not be accepted by JoinAuthor", name)
+		}
+	}
+}
+
+func TestSelfReferentialQueries(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    func() (string, []any)
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name: "belongs_to to the same table",
+			query: func() (string, []any) {
+				return user.Select(
+					user.WithManager(user.Name().Eq("Boss")),
+				)
+			},
+			wantSQL:  "SELECT user.*, (SELECT AS STRUCT * FROM user AS user_1 WHERE user_1.id = user.manager_id AND user_1.name = @p0) AS manager FROM user",
+			wantArgs: []any{"Boss"},
+		},
+		{
+			name: "has_many to the same table",
+			query: func() (string, []any) {
+				return user.Select(
+					user.WithReports(),
+					user.WhereReports(user.Email().Like("%@example.com")),
+				)
+			},
+			wantSQL:  "SELECT user.*, ARRAY(SELECT AS STRUCT * FROM user AS user_1 WHERE user_1.manager_id = user.id) AS reports FROM user WHERE EXISTS(SELECT 1 FROM user AS user_2 WHERE user_2.manager_id = user.id AND user_2.email LIKE @p0)",
+			wantArgs: []any{"%@example.com"},
+		},
+		{
+			name: "nested self-referential subqueries",
+			query: func() (string, []any) {
+				return user.Select(
+					user.WhereManager(
+						user.WhereManager(user.Name().Eq("CEO")),
+					),
+				)
+			},
+			wantSQL:  "SELECT user.* FROM user WHERE EXISTS(SELECT 1 FROM user AS user_1 WHERE user_1.id = user.manager_id AND EXISTS(SELECT 1 FROM user AS user_2 WHERE user_2.id = user_1.manager_id AND user_2.name = @p0))",
+			wantArgs: []any{"CEO"},
+		},
+		{
+			name: "self join",
+			query: func() (string, []any) {
+				return user.Select(
+					user.Columns(user.Name()),
+					user.JoinManager(user.Columns(user.Name())),
+				)
+			},
+			wantSQL:  "SELECT user.name, manager.name FROM user INNER JOIN user AS manager ON manager.id = user.manager_id",
+			wantArgs: nil,
+		},
+		{
+			name: "self-referential subquery correlated across aliases with a NULL manager",
+			query: func() (string, []any) {
+				return user.Select(
+					user.ManagerID().IsNotNull(),
+					user.WhereManager(
+						user.ManagerID().IsNull(),
+						user.CreatedAt().GtColumn(user.Outer(user.CreatedAt())),
+					),
+				)
+			},
+			wantSQL:  "SELECT user.* FROM user WHERE user.manager_id IS NOT NULL AND EXISTS(SELECT 1 FROM user AS user_1 WHERE user_1.id = user.manager_id AND user_1.manager_id IS NULL AND user_1.created_at > user.created_at)",
+			wantArgs: nil,
+		},
+		{
+			name: "repeated joins of the same relation",
+			query: func() (string, []any) {
+				return post.Select(
+					post.JoinTags(tag.Name().Eq("go")),
+					post.JoinTags(tag.Name().Eq("sql")),
+				)
+			},
+			wantSQL:  "SELECT post.* FROM post INNER JOIN post_tag ON post_tag.post_id = post.id INNER JOIN tag AS tags ON tags.id = post_tag.tag_id AND tags.name = @p0 INNER JOIN post_tag AS post_tag_1 ON post_tag_1.post_id = post.id INNER JOIN tag AS tags_1 ON tags_1.id = post_tag_1.tag_id AND tags_1.name = @p1",
+			wantArgs: []any{"go", "sql"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.query()
+			if sql != tt.wantSQL {
+				t.Errorf("SQL mismatch\ngot:  %s\nwant: %s", sql, tt.wantSQL)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Errorf("Args length mismatch\ngot:  %d\nwant: %d", len(args), len(tt.wantArgs))
+			} else {
+				for i, arg := range args {
+					if arg != tt.wantArgs[i] {
+						t.Errorf("Arg[%d] mismatch\ngot:  %v\nwant: %v", i, arg, tt.wantArgs[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNestedEagerLoading(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    func() (string, []any)
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name: "has_many with nested many_to_many",
+			query: func() (string, []any) {
+				return user.Select(
+					user.WithPosts(
+						post.WithTags(tag.Name().Eq("go")),
+						post.Title().Like("%tutorial%"),
+					),
+				)
+			},
+			wantSQL:  "SELECT user.*, ARRAY(SELECT AS STRUCT *, ARRAY(SELECT AS STRUCT tag.* FROM tag INNER JOIN post_tag ON tag.id = post_tag.tag_id WHERE post_tag.post_id = post.id AND tag.name = @p0) AS tags FROM post WHERE post.user_id = user.id AND post.title LIKE @p1) AS posts FROM user",
+			wantArgs: []any{"go", "%tutorial%"},
+		},
+		{
+			name: "three levels with belongs_to",
+			query: func() (string, []any) {
+				return tag.Select(
+					tag.WithPosts(
+						post.WithAuthor(
+							user.WithPosts(post.Columns(post.ID())),
+						),
+					),
+				)
+			},
+			wantSQL:  "SELECT tag.*, ARRAY(SELECT AS STRUCT post.*, (SELECT AS STRUCT *, ARRAY(SELECT AS STRUCT post_1.id FROM post AS post_1 WHERE post_1.user_id = user.id) AS posts FROM user WHERE user.id = post.user_id) AS author FROM post INNER JOIN post_tag ON post.id = post_tag.post_id WHERE post_tag.tag_id = tag.id) AS posts FROM tag",
+			wantArgs: nil,
+		},
+		{
+			name: "has_many ordered and limited per parent",
+			query: func() (string, []any) {
+				return user.Select(
+					user.WithPosts(
+						post.OrderBy(post.CreatedAt(), ast.DirectionDesc),
+						post.Limit(3),
+					),
+				)
+			},
+			wantSQL:  "SELECT user.*, ARRAY(SELECT AS STRUCT * FROM post WHERE post.user_id = user.id ORDER BY post.created_at DESC LIMIT 3) AS posts FROM user",
+			wantArgs: nil,
+		},
+		{
+			name: "many_to_many ordered and limited per parent",
+			query: func() (string, []any) {
+				return post.Select(
+					post.WithTags(
+						tag.Name().Ne("draft"),
+						tag.OrderBy(tag.Name(), ast.DirectionAsc),
+						tag.Limit(5),
+					),
+				)
+			},
+			wantSQL:  "SELECT post.*, ARRAY(SELECT AS STRUCT tag.* FROM tag INNER JOIN post_tag ON tag.id = post_tag.tag_id WHERE post_tag.post_id = post.id AND tag.name != @p0 ORDER BY tag.name ASC LIMIT 5) AS tags FROM post",
+			wantArgs: []any{"draft"},
+		},
+		{
+			name: "ordering inside nested subqueries",
+			query: func() (string, []any) {
+				return user.Select(
+					user.WithPosts(
+						post.WithTags(tag.OrderBy(tag.Name(), ast.DirectionAsc)),
+						post.OrderBy(post.CreatedAt(), ast.DirectionDesc),
+						post.Limit(1),
+					),
+					user.OrderBy(user.Name(), ast.DirectionAsc),
+				)
+			},
+			wantSQL:  "SELECT user.*, ARRAY(SELECT AS STRUCT *, ARRAY(SELECT AS STRUCT tag.* FROM tag INNER JOIN post_tag ON tag.id = post_tag.tag_id WHERE post_tag.post_id = post.id ORDER BY tag.name ASC) AS tags FROM post WHERE post.user_id = user.id ORDER BY post.created_at DESC LIMIT 1) AS posts FROM user ORDER BY user.name ASC",
+			wantArgs: nil,
+		},
+		{
+			name: "nested relation with projection",
+			query: func() (string, []any) {
+				return user.Select(
+					user.Columns(user.ID()),
+					user.WithPosts(
+						post.Columns(post.Title()),
+						post.WithTags(tag.Columns(tag.Name())),
+					),
+				)
+			},
+			wantSQL:  "SELECT user.id, ARRAY(SELECT AS STRUCT post.title, ARRAY(SELECT AS STRUCT tag.name FROM tag INNER JOIN post_tag ON tag.id = post_tag.tag_id WHERE post_tag.post_id = post.id) AS tags FROM post WHERE post.user_id = user.id) AS posts FROM user",
+			wantArgs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.query()
+			if sql != tt.wantSQL {
+				t.Errorf("SQL mismatch\ngot:  %s\nwant: %s", sql, tt.wantSQL)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Errorf("Args length mismatch\ngot:  %d\nwant: %d", len(args), len(tt.wantArgs))
+			} else {
+				for i, arg := range args {
+					if arg != tt.wantArgs[i] {
+						t.Errorf("Arg[%d] mismatch\ngot:  %v\nwant: %v", i, arg, tt.wantArgs[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestColumnComparisons(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    func() (string, []any)
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name: "columns of the same table",
+			query: func() (string, []any) {
+				return post.Select(
+					post.Title().NeColumn(post.Content()),
+				)
+			},
+			wantSQL:  "SELECT post.* FROM post WHERE post.title != post.content",
+			wantArgs: nil,
+		},
+		{
+			name: "child column against the enclosing parent",
+			query: func() (string, []any) {
+				return user.Select(
+					user.WherePosts(
+						post.CreatedAt().LtColumn(post.Outer(user.CreatedAt())),
+						post.Title().Eq("Hello"),
+					),
+				)
+			},
+			wantSQL:  "SELECT user.* FROM user WHERE EXISTS(SELECT 1 FROM post WHERE post.user_id = user.id AND post.created_at < user.created_at AND post.title = @p0)",
+			wantArgs: []any{"Hello"},
+		},
+		{
+			name: "outer column without an enclosing scope keeps its table",
+			query: func() (string, []any) {
+				return post.Select(
+					post.CreatedAt().GtColumn(post.Outer(user.CreatedAt())),
+					post.Title().Eq("Hello"),
+				)
+			},
+			wantSQL:  "SELECT post.* FROM post WHERE post.created_at > user.created_at AND post.title = @p0",
+			wantArgs: []any{"Hello"},
+		},
+		{
+			name: "enclosing scope of the same table is qualified by its alias",
+			query: func() (string, []any) {
+				return user.Select(
+					user.WhereManager(
+						user.WhereManager(user.CreatedAt().GtColumn(user.Outer(user.CreatedAt()))),
+					),
+				)
+			},
+			wantSQL:  "SELECT user.* FROM user WHERE EXISTS(SELECT 1 FROM user AS user_1 WHERE user_1.id = user.manager_id AND EXISTS(SELECT 1 FROM user AS user_2 WHERE user_2.id = user_1.manager_id AND user_2.created_at > user_1.created_at))",
+			wantArgs: nil,
+		},
+		{
+			name: "enclosing scope skipped by nested relations",
+			query: func() (string, []any) {
+				return user.Select(
+					user.WithPosts(
+						post.WithTags(tag.Name().EqColumn(tag.Outer(user.Name()))),
+					),
+				)
+			},
+			wantSQL:  "SELECT user.*, ARRAY(SELECT AS STRUCT *, ARRAY(SELECT AS STRUCT tag.* FROM tag INNER JOIN post_tag ON tag.id = post_tag.tag_id WHERE post_tag.post_id = post.id AND tag.name = user.name) AS tags FROM post WHERE post.user_id = user.id) AS posts FROM user",
+			wantArgs: nil,
+		},
+		{
+			name: "joined column against the base table",
+			query: func() (string, []any) {
+				return post.Select(
+					post.Title().Eq("Hello"),
+					post.JoinAuthor(user.CreatedAt().LeColumn(user.Outer(post.CreatedAt()))),
+				)
+			},
+			wantSQL:  "SELECT post.* FROM post INNER JOIN user AS author ON author.id = post.user_id AND author.created_at <= post.created_at WHERE post.title = @p0",
+			wantArgs: []any{"Hello"},
+		},
+		{
+			name: "aggregate against a column",
+			query: func() (string, []any) {
+				return post.Select(
+					post.Columns(post.UserID()),
+					post.GroupBy(post.UserID(), post.Title()),
+					post.Having(post.Content().Max().NeColumn(post.Title())),
+				)
+			},
+			wantSQL:  "SELECT post.user_id FROM post GROUP BY post.user_id, post.title HAVING MAX(post.content) != post.title",
+			wantArgs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.query()
+			if sql != tt.wantSQL {
+				t.Errorf("SQL mismatch\ngot:  %s\nwant: %s", sql, tt.wantSQL)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Errorf("Args length mismatch\ngot:  %d\nwant: %d", len(args), len(tt.wantArgs))
+			} else {
+				for i, arg := range args {
+					if arg != tt.wantArgs[i] {
+						t.Errorf("Arg[%d] mismatch\ngot:  %v\nwant: %v", i, arg, tt.wantArgs[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFunctionQueries(t *testing.T) {
+	none := "none"
+	tests := []struct {
+		name     string
+		query    func() (string, []any)
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name: "compare a function of a column",
+			query: func() (string, []any) {
+				return user.Select(
+					user.Email().Lower().Eq("a@b"),
+				)
+			},
+			wantSQL:  "SELECT user.* FROM user WHERE LOWER(user.email) = @p0",
+			wantArgs: []any{"a@b"},
+		},
+		{
+			name: "string predicates",
+			query: func() (string, []any) {
+				return user.Select(
+					user.Name().StartsWith("J"),
+					user.Email().EndsWith("@example.com"),
+					user.Email().RegexpContains(`^[a-z]+@`),
+				)
+			},
+			wantSQL:  "SELECT user.* FROM user WHERE STARTS_WITH(user.name, @p0) AND ENDS_WITH(user.email, @p1) AND REGEXP_CONTAINS(user.email, @p2)",
+			wantArgs: []any{"J", "@example.com", `^[a-z]+@`},
+		},
+		{
+			name: "order by truncated timestamp",
+			query: func() (string, []any) {
+				return post.Select(
+					post.OrderBy(post.CreatedAt().Trunc(types.Day), ast.DirectionDesc),
+				)
+			},
+			wantSQL:  "SELECT post.* FROM post ORDER BY TIMESTAMP_TRUNC(post.created_at, DAY) DESC",
+			wantArgs: nil,
+		},
+		{
+			name: "group by extracted part",
+			query: func() (string, []any) {
+				return post.Select(
+					post.Columns(post.CreatedAt().Extract(types.Year).As("year"), post.CountAll().As("count")),
+					post.GroupBy(post.CreatedAt().Extract(types.Year)),
+					post.Having(post.CreatedAt().Extract(types.Year).Ge(2020)),
+				)
+			},
+			wantSQL:  "SELECT EXTRACT(YEAR FROM post.created_at) AS year, COUNT(*) AS count FROM post GROUP BY EXTRACT(YEAR FROM post.created_at) HAVING EXTRACT(YEAR FROM post.created_at) >= @p0",
+			wantArgs: []any{int64(2020)},
+		},
+		{
+			name: "truncate to a time part and extract an extract-only part",
+			query: func() (string, []any) {
+				return post.Select(
+					post.Columns(post.CreatedAt().Trunc(types.Hour).As("hour")),
+					post.CreatedAt().Extract(types.DayOfWeek).Eq(1),
+				)
+			},
+			wantSQL:  "SELECT TIMESTAMP_TRUNC(post.created_at, HOUR) AS hour FROM post WHERE EXTRACT(DAYOFWEEK FROM post.created_at) = @p0",
+			wantArgs: []any{int64(1)},
+		},
+		{
+			name: "length of a string",
+			query: func() (string, []any) {
+				return post.Select(
+					post.Title().Length().Gt(10),
+					post.OrderBy(post.Content().Length(), ast.DirectionAsc),
+				)
+			},
+			wantSQL:  "SELECT post.* FROM post WHERE LENGTH(post.title) > @p0 ORDER BY LENGTH(post.content) ASC",
+			wantArgs: []any{int64(10)},
+		},
+		{
+			name: "coalesce and ifnull",
+			query: func() (string, []any) {
+				return user.Select(
+					user.Columns(types.IfNull(user.ManagerID(), &none).As("manager")),
+					types.Coalesce(user.Name(), user.Email()).Ne("x"),
+				)
+			},
+			wantSQL:  "SELECT IFNULL(user.manager_id, @p0) AS manager FROM user WHERE COALESCE(user.name, user.email) != @p1",
+			wantArgs: []any{&none, "x"},
+		},
+		{
+			name: "cast and safe cast",
+			query: func() (string, []any) {
+				return post.Select(
+					types.SafeCast(post.Title(), types.Int64Type).Gt(0),
+					post.OrderBy(types.Cast(post.CreatedAt(), types.StringType), ast.DirectionAsc),
+				)
+			},
+			wantSQL:  "SELECT post.* FROM post WHERE SAFE_CAST(post.title AS INT64) > @p0 ORDER BY CAST(post.created_at AS STRING) ASC",
+			wantArgs: []any{int64(0)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.query()
+			if sql != tt.wantSQL {
+				t.Errorf("SQL mismatch\ngot:  %s\nwant: %s", sql, tt.wantSQL)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Errorf("Args length mismatch\ngot:  %d\nwant: %d", len(args), len(tt.wantArgs))
+			} else {
+				for i, arg := range args {
+					if arg != tt.wantArgs[i] {
+						t.Errorf("Arg[%d] mismatch\ngot:  %v\nwant: %v", i, arg, tt.wantArgs[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDatePartKinds(t *testing.T) {
+	parts := []struct {
+		part           any
+		timestampTrunc bool
+		dateTrunc      bool
+		dateExtract    bool
+	}{
+		{types.Hour, true, false, false},
+		{types.Day, true, true, true},
+		{types.DayOfWeek, false, false, true},
+		{types.DayOfYear, false, false, true},
+	}
+	for _, p := range parts {
+		if _, ok := p.part.(types.TimestampTruncPart); ok != p.timestampTrunc {
+			t.Errorf("%v accepted by TimeColumn.Trunc: %v, want %v", p.part, ok, p.timestampTrunc)
+		}
+		if _, ok := p.part.(types.DatePart); ok != p.dateTrunc {
+			t.Errorf("%v accepted by DateColumn.Trunc: %v, want %v", p.part, ok, p.dateTrunc)
+		}
+		if _, ok := p.part.(types.DateExtractPart); ok != p.dateExtract {
+			t.Errorf("%v accepted by DateColumn.Extract: %v, want %v", p.part, ok, p.dateExtract)
+		}
+		if _, ok := p.part.(types.TimestampExtractPart); !ok {
+			t.Errorf("%v should be accepted by TimeColumn.Extract", p.part)
+		}
+	}
+}
+
+func TestArithmeticQueries(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    func() (string, []any)
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name: "sum of columns in WHERE",
+			query: func() (string, []any) {
+				return post.Select(
+					post.Views().Add(post.Likes()).Gt(100),
+				)
+			},
+			wantSQL:  "SELECT post.* FROM post WHERE post.views + post.likes > @p0",
+			wantArgs: []any{int64(100)},
+		},
+		{
+			name: "computed column appended to all columns",
+			query: func() (string, []any) {
+				return post.Select(
+					post.AddColumns(post.Views().Mul(post.Likes()).As("total")),
+				)
+			},
+			wantSQL:  "SELECT post.*, post.views * post.likes AS total FROM post",
+			wantArgs: nil,
+		},
+		{
+			name: "parentheses follow precedence",
+			query: func() (string, []any) {
+				return post.Select(
+					post.Columns(
+						post.ID(),
+						post.Views().Add(post.Likes()).Mul(post.Param(int64(2))).As("weighted"),
+						post.Views().Sub(post.Likes().Neg()).As("spread"),
+					),
+					post.OrderBy(post.Likes().Div(post.Views()), ast.DirectionDesc),
+				)
+			},
+			wantSQL:  "SELECT post.id, (post.views + post.likes) * @p0 AS weighted, post.views - -post.likes AS spread FROM post ORDER BY post.likes / post.views DESC",
+			wantArgs: []any{int64(2)},
+		},
+		{
+			name: "right operand of the same precedence keeps its grouping",
+			query: func() (string, []any) {
+				return post.Select(
+					post.Columns(
+						post.Views().Sub(post.Likes().Sub(post.Param(int64(2)))).As("diff"),
+						post.Views().Div(post.Likes().Mul(post.Param(int64(3)))).As("ratio"),
+					),
+				)
+			},
+			wantSQL:  "SELECT post.views - (post.likes - @p0) AS diff, post.views / (post.likes * @p1) AS ratio FROM post",
+			wantArgs: []any{int64(2), int64(3)},
+		},
+		{
+			name: "double negation is not a comment",
+			query: func() (string, []any) {
+				return post.Select(
+					post.Columns(post.Views().Neg().Neg().As("views")),
+				)
+			},
+			wantSQL:  "SELECT -(-post.views) AS views FROM post",
+			wantArgs: nil,
+		},
+		{
+			name: "string concatenation",
+			query: func() (string, []any) {
+				return user.Select(
+					user.Columns(user.Name().Concat(user.Param(" <"), user.Email(), user.Param(">")).As("display")),
+					user.Name().Lower().Concat(user.Email()).Ne("x"),
+				)
+			},
+			wantSQL:  "SELECT user.name || @p0 || user.email || @p1 AS display FROM user WHERE LOWER(user.name) || user.email != @p2",
+			wantArgs: []any{" <", ">", "x"},
+		},
+		{
+			name: "computed column inside a relationship subquery",
+			query: func() (string, []any) {
+				return user.Select(
+					user.WithPosts(
+						post.AddColumns(post.Views().Add(post.Likes()).As("score")),
+						post.Likes().GtColumn(post.Views().Abs()),
+					),
+				)
+			},
+			wantSQL:  "SELECT user.*, ARRAY(SELECT AS STRUCT *, post.views + post.likes AS score FROM post WHERE post.user_id = user.id AND post.likes > ABS(post.views)) AS posts FROM user",
+			wantArgs: nil,
+		},
+		{
+			name: "division of a NUMERIC column stays NUMERIC",
+			query: func() (string, []any) {
+				score := types.DecimalColumn[tables.Post, big.Rat]{NumericColumn: types.NumericColumn[tables.Post, big.Rat]{Column: types.Column[tables.Post, big.Rat]{Name: "score"}}}
+				var ratio types.NumericExpr[tables.Post, big.Rat] = score.Div(score)
+				return post.Select(
+					post.Columns(ratio.As("ratio")),
+				)
+			},
+			wantSQL:  "SELECT post.score / post.score AS ratio FROM post",
+			wantArgs: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.query()
+			if sql != tt.wantSQL {
+				t.Errorf("SQL mismatch\ngot:  %s\nwant: %s", sql, tt.wantSQL)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Errorf("Args length mismatch\ngot:  %d\nwant: %d", len(args), len(tt.wantArgs))
+			} else {
+				for i, arg := range args {
+					if arg != tt.wantArgs[i] {
+						t.Errorf("Arg[%d] mismatch\ngot:  %v\nwant: %v", i, arg, tt.wantArgs[i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestCaseQueries(t *testing.T) {
+	tests := []struct {
+		name     string
+		query    func() (string, []any)
+		wantSQL  string
+		wantArgs []any
+	}{
+		{
+			name: "bucket values in projection",
+			query: func() (string, []any) {
+				return post.Select(
+					post.Columns(
+						post.ID(),
+						query.Case(post.Views().Ge(1000), "hot").
+							When(post.Views().Ge(100), "warm").
+							Else("cold").As("popularity"),
+					),
+					post.Title().Ne(""),
+				)
+			},
+			wantSQL:  "SELECT post.id, CASE WHEN post.views >= @p0 THEN @p1 WHEN post.views >= @p2 THEN @p3 ELSE @p4 END AS popularity FROM post WHERE post.title != @p5",
+			wantArgs: []any{int64(1000), "hot", int64(100), "warm", "cold", ""},
+		},
+		{
+			name: "custom priority ordering",
+			query: func() (string, []any) {
+				return post.Select(
+					post.OrderBy(
+						query.Case(post.Title().StartsWith("[pinned]"), int64(0)).
+							When(post.WhereTags(tag.Name().Eq("news")), int64(1)).
+							Else(int64(2)),
+						ast.DirectionAsc,
+					),
+					post.OrderBy(post.CreatedAt(), ast.DirectionDesc),
+				)
+			},
+			wantSQL:  "SELECT post.* FROM post ORDER BY CASE WHEN STARTS_WITH(post.title, @p0) THEN @p1 WHEN EXISTS(SELECT 1 FROM tag INNER JOIN post_tag ON tag.id = post_tag.tag_id WHERE post_tag.post_id = post.id AND tag.name = @p2) THEN @p3 ELSE @p4 END ASC, post.created_at DESC",
+			wantArgs: []any{"[pinned]", int64(0), "news", int64(1), int64(2)},
+		},
+		{
+			name: "compare a case expression",
+			query: func() (string, []any) {
+				return post.Select(
+					query.CaseColumn(post.Views().Gt(0), post.Likes()).Else(0).GtColumn(post.Param(int64(10))),
+				)
+			},
+			wantSQL:  "SELECT post.* FROM post WHERE CASE WHEN post.views > @p0 THEN post.likes ELSE @p1 END > @p2",
+			wantArgs: []any{int64(0), int64(0), int64(10)},
+		},
+		{
+			name: "branches of columns without ELSE",
+			query: func() (string, []any) {
+				return user.Select(
+					user.Columns(
+						query.CaseColumn(user.Or(user.Name().Eq(""), user.Name().IsNull()), user.Email()).
+							WhenColumn(user.Email().IsNull(), user.ID()).
+							End().As("label"),
+					),
+				)
+			},
+			wantSQL:  "SELECT CASE WHEN (user.name = @p0 OR user.name IS NULL) THEN user.email WHEN user.email IS NULL THEN user.id END AS label FROM user",
+			wantArgs: []any{""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sql, args := tt.query()
+			if sql != tt.wantSQL {
+				t.Errorf("SQL mismatch\ngot:  %s\nwant: %s", sql, tt.wantSQL)
+			}
+			if len(args) != len(tt.wantArgs) {
+				t.Errorf("Args length mismatch\ngot:  %d\nwant: %d", len(args), len(tt.wantArgs))
+			} else {
+				for i, arg := range args {
+					if arg != tt.wantArgs[i] {
+						t.Errorf("Arg[%d] mismatch\ngot:  %v\nwant: %v", i, arg, tt.wantArgs[i])
+					}
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+CREATE TABLE user (
+  id STRING(36) NOT NULL,
+  name STRING(MAX) NOT NULL,
+  email STRING(MAX) NOT NULL,
+  manager_id STRING(36),
+  created_at TIMESTAMP NOT NULL,
+  CONSTRAINT FK_user_manager FOREIGN KEY (manager_id) REFERENCES user (id)
+) PRIMARY KEY (id);
+
+CREATE TABLE post (
+  id STRING(36) NOT NULL,
+  user_id STRING(36) NOT NULL,
+  title STRING(MAX) NOT NULL,
+  content STRING(MAX) NOT NULL,
+  views INT64 NOT NULL,
+  likes INT64 NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  CONSTRAINT FK_post_author FOREIGN KEY (user_id) REFERENCES user (id)
+) PRIMARY KEY (id);
+
+CREATE TABLE tag (
+  id STRING(36) NOT NULL,
+  name STRING(MAX) NOT NULL
+) PRIMARY KEY (id);
+
+CREATE TABLE post_tag (
+  post_id STRING(36) NOT NULL,
+  tag_id STRING(36) NOT NULL,
+  created_at TIMESTAMP NOT NULL,
+  CONSTRAINT FK_post_tag_post FOREIGN KEY (post_id) REFERENCES post (id),
+  CONSTRAINT FK_post_tag_tag FOREIGN KEY (tag_id) REFERENCES tag (id)
+) PRIMARY KEY (post_id, tag_id);
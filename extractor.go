@@ -3,14 +3,21 @@ package plate
 import (
 	"fmt"
 	"reflect"
+	"sort"
+	"strings"
 )
 
 // columnInfo represents extracted column information
 type columnInfo struct {
-	Name        string // Field name (e.g., "ID", "UserID")
-	GoType      string // Go type string (e.g., "string", "int64")
-	SpannerType string // Spanner type from tag (e.g., "STRING", "INT64")
-	ColumnName  string // Database column name from spanner tag
+	Name        string   // Field name (e.g., "ID", "UserID")
+	GoType      string   // Go type string (e.g., "string", "int64")
+	Imports     []string // Import paths of the packages named in GoType (e.g., "example.com/app/models")
+	SpannerType string   // Spanner type from tag (e.g., "STRING", "INT64")
+	ColumnName  string   // Database column name from spanner tag
+	NotNull     bool     // Whether the column is NOT NULL
+	Doc         string   // Field doc comment, empty for models read with reflection
+	Relation    string   // plate tag declaring a relation, see parseRelationTag
+	Pos         string   // Source position of the field, empty for models read with reflection
 }
 
 // extractColumns extracts column information from a model using reflection
@@ -47,8 +54,11 @@ func extractColumns(model interface{}) []columnInfo {
 		columns = append(columns, columnInfo{
 			Name:        field.Name,
 			GoType:      getGoTypeString(field.Type),
+			Imports:     typeImportPaths(field.Type),
 			SpannerType: spannerType,
 			ColumnName:  spannerTag,
+			NotNull:     !isNullableType(field.Type),
+			Relation:    field.Tag.Get("plate"),
 		})
 	}
 
@@ -76,6 +86,28 @@ func getGoTypeString(t reflect.Type) string {
 	return t.Name()
 }
 
+// typeImportPaths returns the import paths of the named types a Go type is built from
+func typeImportPaths(t reflect.Type) []string {
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Ptr:
+		return typeImportPaths(t.Elem())
+	}
+	if t.PkgPath() == "" {
+		return nil
+	}
+	return []string{t.PkgPath()}
+}
+
+// isNullableType reports whether values of the Go type can hold NULL
+// Pointers, slices and Null* types such as spanner.NullString are nullable
+func isNullableType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	}
+	return strings.HasPrefix(t.Name(), "Null")
+}
+
 // inferSpannerType attempts to infer Spanner type from Go type
 // This is a fallback when spannerType tag is not provided
 func inferSpannerType(t reflect.Type) string {
@@ -111,3 +143,95 @@ func inferSpannerType(t reflect.Type) string {
 	// Default to empty string if we can't infer
 	return ""
 }
+
+// typeImports maps packages used by column Go types to their import paths
+var typeImports = map[string]string{
+	"time":    "time",
+	"civil":   "cloud.google.com/go/civil",
+	"big":     "math/big",
+	"spanner": "cloud.google.com/go/spanner",
+}
+
+// columnImports returns the import paths needed by the Go types of the columns
+// Paths recorded in Imports are used as they are, and well-known packages are looked up by name
+func columnImports(columns []columnInfo) []string {
+	seen := make(map[string]bool)
+	var imports []string
+	add := func(path string) {
+		if seen[path] {
+			return
+		}
+		seen[path] = true
+		imports = append(imports, path)
+	}
+	for _, col := range columns {
+		for _, path := range col.Imports {
+			add(path)
+		}
+		pkg, _, ok := strings.Cut(strings.TrimLeft(col.GoType, "[]*"), ".")
+		if !ok {
+			continue
+		}
+		if path, known := typeImports[pkg]; known {
+			add(path)
+		}
+	}
+	sort.Strings(imports)
+	return imports
+}
+
+// columnKinds maps Spanner types to the column kinds of the types package
+var columnKinds = map[string]string{
+	"STRING":    "String",
+	"INT64":     "Numeric",
+	"FLOAT32":   "Numeric",
+	"FLOAT64":   "Numeric",
+	"NUMERIC":   "Decimal",
+	"BOOL":      "Bool",
+	"TIMESTAMP": "Time",
+	"DATE":      "Date",
+	"BYTES":     "Bytes",
+	"JSON":      "JSON",
+}
+
+// Kind returns the column kind of the generated accessor (e.g., "String" for types.StringColumn),
+// or an empty string when the Spanner type has no kind and the accessor returns types.Column
+func (c columnInfo) Kind() string {
+	spannerType := strings.ToUpper(lengthPattern.ReplaceAllString(strings.ReplaceAll(c.SpannerType, " ", ""), ""))
+	if strings.HasPrefix(spannerType, "ARRAY<") {
+		if !strings.HasPrefix(c.GoType, "[]") {
+			return ""
+		}
+		return "Array"
+	}
+	return columnKinds[spannerType]
+}
+
+// AccessorType returns the type returned by the column accessor of the table
+func (c columnInfo) AccessorType(typeName string) string {
+	switch kind := c.Kind(); kind {
+	case "":
+		return fmt.Sprintf("types.Column[tables.%s, %s]", typeName, c.GoType)
+	case "Array":
+		return fmt.Sprintf("types.ArrayColumn[tables.%s, %s]", typeName, strings.TrimPrefix(c.GoType, "[]"))
+	default:
+		return fmt.Sprintf("types.%sColumn[tables.%s, %s]", kind, typeName, c.GoType)
+	}
+}
+
+// AccessorValue returns the composite literal returned by the column accessor of the table
+func (c columnInfo) AccessorValue(typeName string) string {
+	column := fmt.Sprintf("types.Column[tables.%s, %s]{Name: %q}", typeName, c.GoType, c.ColumnName)
+	switch c.Kind() {
+	case "":
+		return column
+	case "Decimal":
+		numeric := fmt.Sprintf("types.NumericColumn[tables.%s, %s]{Column: %s}", typeName, c.GoType, column)
+		return fmt.Sprintf("%s{NumericColumn: %s}", c.AccessorType(typeName), numeric)
+	case "Array", "JSON":
+		base := fmt.Sprintf("types.BaseColumn[tables.%s, %s]{Name: %q}", typeName, c.GoType, c.ColumnName)
+		return fmt.Sprintf("%s{BaseColumn: %s}", c.AccessorType(typeName), base)
+	default:
+		return fmt.Sprintf("%s{Column: %s}", c.AccessorType(typeName), column)
+	}
+}
@@ -19,6 +19,7 @@ type Schema struct {
 type GenerateOptions struct {
 	OutputDir string
 	Clean     bool
+	Models    bool // Also generate model structs for tables without a Model (see GenerateModelFiles)
 }
 
 // TableConfig represents a table that needs a query builder
@@ -34,9 +35,47 @@ type JunctionConfig struct {
 }
 
 // TableSchema contains the basic information about a table
+// Either Model or TypeName and Columns must be set; tables loaded from DDL have no Model
 type TableSchema struct {
-	TableName string      // Database table name (e.g., "user")
-	Model     interface{} // Model instance (e.g., models.User{})
+	TableName string         // Database table name (e.g., "user")
+	Model     interface{}    // Model instance (e.g., models.User{})
+	TypeName  string         // Go type name, used when Model is nil (e.g., "User")
+	Columns   []ColumnSchema // Column definitions, used when Model is nil
+
+	PrimaryKey      []IndexKey    // Primary key columns
+	Indexes         []IndexSchema // Secondary indexes
+	Parent          string        // Table this table is interleaved in, if any
+	OnDeleteCascade bool          // Whether rows are deleted with their parent row
+	Junction        bool          // Whether the model carries the junction marker, used when Model is nil
+	Pos             string        // Source position of the model ("file:line:col"), set by LoadModels
+}
+
+// ColumnSchema describes a column of a table without a Model
+type ColumnSchema struct {
+	Name        string   // Go field name (e.g., "UserID")
+	ColumnName  string   // Database column name (e.g., "user_id")
+	SpannerType string   // Spanner type (e.g., "STRING(MAX)")
+	GoType      string   // Go type of values (e.g., "string")
+	Imports     []string // Import paths of the packages named in GoType, set by LoadModels
+	NotNull     bool     // Whether the column is NOT NULL
+	Doc         string   // Field doc comment, carried into the generated column accessor
+	Relation    string   // plate tag declaring a relation (e.g., "belongs_to=User,reverse=Posts")
+	Pos         string   // Source position of the field ("file:line:col"), set by LoadModels
+}
+
+// IndexKey is a column of a primary key or index
+type IndexKey struct {
+	Column string // Database column name
+	Desc   bool   // Whether the key is sorted in descending order
+}
+
+// IndexSchema describes a secondary index
+type IndexSchema struct {
+	Name         string
+	Keys         []IndexKey
+	Unique       bool
+	NullFiltered bool
+	Storing      []string // Column names stored in the index
 }
 
 // Relation represents a belongs_to relationship
@@ -46,6 +85,7 @@ type Relation struct {
 	From        string // Source column (e.g., "UserID")
 	To          string // Target column (e.g., "ID")
 	ReverseName string // Optional: Name for the reverse HasMany relation (e.g., "Posts")
+	Pos         string // Optional: Source position of the declaration, set for plate tags
 }
 
 // Generator is responsible for generating query builder code
@@ -61,6 +101,10 @@ func NewGenerator() *Generator {
 
 // GenerateFiles generates query builder code and returns the files without writing them
 func (g *Generator) GenerateFiles(schema Schema, outputDir string) (GeneratedFiles, error) {
+	schema, err := g.withTagRelations(schema)
+	if err != nil {
+		return GeneratedFiles{}, fmt.Errorf("invalid configuration: %w", err)
+	}
 	g.schema = schema
 	g.outputDir = outputDir
 
@@ -71,7 +115,7 @@ func (g *Generator) GenerateFiles(schema Schema, outputDir string) (GeneratedFil
 
 	// Build internal data structures
 	tableMap := g.buildTableMap()
-	relationMap := g.buildRelationMap()
+	relationMap := g.buildRelationMap(tableMap)
 
 	// Generate files
 	files := make(map[string]string)
@@ -100,45 +144,82 @@ func (g *Generator) GenerateFiles(schema Schema, outputDir string) (GeneratedFil
 
 // Generate generates and writes query builder code to the output directory
 func (g *Generator) Generate(schema Schema, opts GenerateOptions) error {
+	if opts.Models {
+		schema = withModelFieldTypes(schema)
+	}
 	files, err := g.GenerateFiles(schema, opts.OutputDir)
 	if err != nil {
 		return err
 	}
 
+	if opts.Models {
+		models, err := g.GenerateModelFiles(schema)
+		if err != nil {
+			return err
+		}
+		for path, code := range models.Files {
+			files.Files[path] = code
+		}
+	}
+
 	if opts.Clean {
 		return files.WriteToDirectoryClean(opts.OutputDir)
 	}
 	return files.WriteToDirectory(opts.OutputDir)
 }
 
-// validateConfig validates the generator configuration
-func (g *Generator) validateConfig() error {
-	// Check for duplicate table names
-	seen := make(map[string]bool)
+// getTypeName extracts the type name from a model
+func (g *Generator) getTypeName(schema TableSchema) string {
+	if schema.Model == nil {
+		return schema.TypeName
+	}
+	t := reflect.TypeOf(schema.Model)
+	return t.Name()
+}
 
-	for _, tc := range g.schema.Tables {
-		name := g.getTypeName(tc.Schema)
-		if seen[name] {
-			return fmt.Errorf("duplicate table: %s", name)
-		}
-		seen[name] = true
+// getColumns extracts the columns of a table from its model or column definitions
+func (g *Generator) getColumns(schema TableSchema) []columnInfo {
+	if schema.Model != nil {
+		return extractColumns(schema.Model)
 	}
 
-	// Validate junction tables have exactly 2 relations
-	for _, jc := range g.schema.Junctions {
-		if len(jc.Relations) != 2 {
-			name := g.getTypeName(jc.Schema)
-			return fmt.Errorf("junction table %s must have exactly 2 relations, got %d", name, len(jc.Relations))
-		}
+	columns := make([]columnInfo, 0, len(schema.Columns))
+	for _, col := range schema.Columns {
+		columns = append(columns, columnInfo{
+			Name:        col.Name,
+			GoType:      col.GoType,
+			Imports:     col.Imports,
+			SpannerType: col.SpannerType,
+			ColumnName:  col.ColumnName,
+			NotNull:     col.NotNull,
+			Doc:         col.Doc,
+			Relation:    col.Relation,
+			Pos:         col.Pos,
+		})
 	}
+	return columns
+}
 
-	return nil
+// columnName resolves the database column name of a field of the given table
+// Falls back to snake_case when the table or field is unknown
+func (g *Generator) columnName(tableMap map[string]TableSchema, typeName, field string) string {
+	if schema, ok := tableMap[typeName]; ok {
+		for _, col := range g.getColumns(schema) {
+			if col.Name == field {
+				return col.ColumnName
+			}
+		}
+	}
+	return toSnakeCase(field)
 }
 
-// getTypeName extracts the type name from a model
-func (g *Generator) getTypeName(schema TableSchema) string {
-	t := reflect.TypeOf(schema.Model)
-	return t.Name()
+// tableName resolves the database table name of the given type
+// Falls back to snake_case when the type is unknown
+func (g *Generator) tableName(tableMap map[string]TableSchema, typeName string) string {
+	if schema, ok := tableMap[typeName]; ok && schema.TableName != "" {
+		return schema.TableName
+	}
+	return toSnakeCase(typeName)
 }
 
 // toPackageName converts a type name to a package name
@@ -166,8 +247,12 @@ func (g *Generator) buildTableMap() map[string]TableSchema {
 }
 
 // buildRelationMap builds a map of relations including derived ones
-func (g *Generator) buildRelationMap() map[string][]generatedRelation {
+// Keys are resolved to database column names so the generated SQL does not depend on naming conventions
+func (g *Generator) buildRelationMap(tableMap map[string]TableSchema) map[string][]generatedRelation {
 	relations := make(map[string][]generatedRelation)
+	col := func(typeName, field string) string {
+		return g.columnName(tableMap, typeName, field)
+	}
 
 	// 1. Process BelongsTo relations from regular tables
 	for _, tc := range g.schema.Tables {
@@ -176,19 +261,21 @@ func (g *Generator) buildRelationMap() map[string][]generatedRelation {
 		for _, rel := range tc.Relations {
 			// Add BelongsTo relation
 			relations[typeName] = append(relations[typeName], generatedRelation{
-				Name:   rel.Name,
-				Type:   "belongs_to",
-				Target: rel.Target,
-				Keys:   query.KeyPair{From: rel.From, To: rel.To},
+				Name:        rel.Name,
+				Type:        "belongs_to",
+				Target:      rel.Target,
+				TargetTable: g.tableName(tableMap, rel.Target),
+				Keys:        query.KeyPair{From: col(typeName, rel.From), To: col(rel.Target, rel.To)},
 			})
 
 			// Generate reverse HasMany relation if ReverseName is specified
 			if rel.ReverseName != "" {
 				relations[rel.Target] = append(relations[rel.Target], generatedRelation{
-					Name:   rel.ReverseName,
-					Type:   "has_many",
-					Target: typeName,
-					Keys:   query.KeyPair{From: rel.To, To: rel.From},
+					Name:        rel.ReverseName,
+					Type:        "has_many",
+					Target:      typeName,
+					TargetTable: g.tableName(tableMap, typeName),
+					Keys:        query.KeyPair{From: col(rel.Target, rel.To), To: col(typeName, rel.From)},
 				})
 			}
 		}
@@ -201,6 +288,7 @@ func (g *Generator) buildRelationMap() map[string][]generatedRelation {
 		}
 
 		junctionName := g.getTypeName(jc.Schema)
+		junctionTable := g.tableName(tableMap, junctionName)
 		rel1 := jc.Relations[0]
 		rel2 := jc.Relations[1]
 
@@ -210,9 +298,10 @@ func (g *Generator) buildRelationMap() map[string][]generatedRelation {
 				Name:          rel1.ReverseName,
 				Type:          "many_to_many",
 				Target:        rel2.Target,
-				Keys:          query.KeyPair{From: rel1.To, To: rel1.From},
-				JunctionTable: junctionName,
-				JunctionKeys:  query.KeyPair{From: rel2.From, To: rel2.To},
+				TargetTable:   g.tableName(tableMap, rel2.Target),
+				Keys:          query.KeyPair{From: col(rel1.Target, rel1.To), To: col(junctionName, rel1.From)},
+				JunctionTable: junctionTable,
+				JunctionKeys:  query.KeyPair{From: col(junctionName, rel2.From), To: col(rel2.Target, rel2.To)},
 			})
 		}
 
@@ -222,9 +311,10 @@ func (g *Generator) buildRelationMap() map[string][]generatedRelation {
 				Name:          rel2.ReverseName,
 				Type:          "many_to_many",
 				Target:        rel1.Target,
-				Keys:          query.KeyPair{From: rel2.To, To: rel2.From},
-				JunctionTable: junctionName,
-				JunctionKeys:  query.KeyPair{From: rel1.From, To: rel1.To},
+				TargetTable:   g.tableName(tableMap, rel1.Target),
+				Keys:          query.KeyPair{From: col(rel2.Target, rel2.To), To: col(junctionName, rel2.From)},
+				JunctionTable: junctionTable,
+				JunctionKeys:  query.KeyPair{From: col(junctionName, rel1.From), To: col(rel1.Target, rel1.To)},
 			})
 		}
 	}
@@ -237,9 +327,10 @@ type generatedRelation struct {
 	Name          string
 	Type          string // "belongs_to", "has_many", "many_to_many"
 	Target        string
-	Keys          query.KeyPair
-	JunctionTable string        // For many_to_many
-	JunctionKeys  query.KeyPair // For many_to_many
+	TargetTable   string        // Database table name of Target
+	Keys          query.KeyPair // Database column names
+	JunctionTable string        // Database table name, for many_to_many
+	JunctionKeys  query.KeyPair // Database column names, for many_to_many
 }
 
 // generateTablesPackage generates the tables package containing all table definitions
@@ -249,18 +340,16 @@ func (g *Generator) generateTablesPackage(tableMap map[string]TableSchema) (stri
 		return "", err
 	}
 
-	// Prepare table data
+	// Prepare table data in schema order so the output is stable
+	// Only include tables that have query builders
 	var tables []tableTemplateData
-	for typeName, schema := range tableMap {
-		// Only include tables that have query builders
-		for _, tc := range g.schema.Tables {
-			if g.getTypeName(tc.Schema) == typeName {
-				tables = append(tables, tableTemplateData{
-					TypeName:  typeName,
-					TableName: schema.TableName,
-				})
-				break
-			}
+	for _, tc := range g.schema.Tables {
+		typeName := g.getTypeName(tc.Schema)
+		if schema, ok := tableMap[typeName]; ok {
+			tables = append(tables, tableTemplateData{
+				TypeName:  typeName,
+				TableName: schema.TableName,
+			})
 		}
 	}
 
@@ -282,7 +371,7 @@ func (g *Generator) generateQueryBuilder(tc TableConfig, tableMap map[string]Tab
 	packageName := g.toPackageName(typeName)
 
 	// Extract columns
-	columns := extractColumns(tc.Schema.Model)
+	columns := g.getColumns(tc.Schema)
 
 	// Get relations for this table
 	relations := relationMap[typeName]
@@ -305,17 +394,8 @@ func (g *Generator) generateQueryBuilder(tc TableConfig, tableMap map[string]Tab
 		plateImportPath + "/types",
 	}
 
-	// Add time import if any column uses time.Time
-	needsTime := false
-	for _, col := range columns {
-		if col.GoType == "time.Time" {
-			needsTime = true
-			break
-		}
-	}
-	if needsTime {
-		imports = append([]string{"time"}, imports...)
-	}
+	// Add imports for column value types such as time.Time
+	imports = append(columnImports(columns), imports...)
 
 	data := templateData{
 		PackageName: packageName,
@@ -0,0 +1,247 @@
+package plate
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/cloudspannerecosystem/memefish/token"
+)
+
+// Migration holds the DDL statements that bring deployed DDL in line with the Go schema
+type Migration struct {
+	Statements []ast.DDL // Statements to apply, in order
+	Review     []Drift   // Destructive or unsafe changes that were not emitted
+}
+
+// SQL renders the statements separated by semicolons
+func (m Migration) SQL() string {
+	return renderDDL(m.Statements)
+}
+
+// DiffSchema compares the schema against Spanner DDL and builds the migration that reconciles them
+// New tables, columns, indexes and foreign keys are emitted in that order. Drops, type changes
+// and new NOT NULL constraints on existing columns are reported in Review instead
+func DiffSchema(schema Schema, r io.Reader) (Migration, error) {
+	l, err := loadDDL(r)
+	if err != nil {
+		return Migration{}, err
+	}
+	return NewGenerator().diffSchema(schema, l)
+}
+
+// DiffSchemaFiles compares the schema against Spanner DDL files applied in order
+func DiffSchemaFiles(schema Schema, paths ...string) (Migration, error) {
+	l, err := loadDDLFiles(paths)
+	if err != nil {
+		return Migration{}, err
+	}
+	return NewGenerator().diffSchema(schema, l)
+}
+
+// diffSchema builds the migration from the loaded DDL to the schema
+func (g *Generator) diffSchema(schema Schema, l *ddlLoader) (Migration, error) {
+	schema, err := g.withTagRelations(schema)
+	if err != nil {
+		return Migration{}, fmt.Errorf("invalid configuration: %w", err)
+	}
+	g.schema = schema
+	if err := g.validateConfig(); err != nil {
+		return Migration{}, fmt.Errorf("invalid configuration: %w", err)
+	}
+	tableMap := g.buildTableMap()
+
+	var sources []ddlSource
+	for _, tc := range schema.Tables {
+		sources = append(sources, ddlSource{schema: tc.Schema, relations: tc.Relations})
+	}
+	for _, jc := range schema.Junctions {
+		sources = append(sources, ddlSource{schema: jc.Schema, relations: jc.Relations, junction: true})
+	}
+
+	var m Migration
+	var newTables []ddlSource
+	var creates, columns, indexes, foreignKeys []ast.DDL
+	inSchema := make(map[string]bool)
+	for _, src := range sources {
+		inSchema[strings.ToLower(src.schema.TableName)] = true
+		t := l.table(src.schema.TableName)
+		if t == nil {
+			newTables = append(newTables, src)
+			continue
+		}
+
+		name := &ast.Path{Idents: []*ast.Ident{{Name: src.schema.TableName}}}
+		stmts, review, err := diffColumns(name, g.getColumns(src.schema), t)
+		if err != nil {
+			return Migration{}, fmt.Errorf("table %s: %w", src.schema.TableName, err)
+		}
+		columns = append(columns, stmts...)
+		m.Review = append(m.Review, review...)
+
+		inIndexes := make(map[string]bool)
+		for _, index := range src.schema.Indexes {
+			inIndexes[strings.ToLower(index.Name)] = true
+			if l.index(index.Name) == nil {
+				indexes = append(indexes, createIndex(src.schema.TableName, index))
+			}
+		}
+		for _, index := range t.schema.Indexes {
+			if !inIndexes[strings.ToLower(index.Name)] {
+				m.Review = append(m.Review, Drift{
+					Kind:    DriftExtraIndex,
+					Table:   src.schema.TableName,
+					Message: fmt.Sprintf("index %s is not in the schema, DROP INDEX needs manual review", index.Name),
+				})
+			}
+		}
+
+		typeName := g.getTypeName(src.schema)
+		for _, rel := range src.relations {
+			from := g.columnName(tableMap, typeName, rel.From)
+			target := g.tableName(tableMap, rel.Target)
+			to := g.columnName(tableMap, rel.Target, rel.To)
+			if l.hasReference(t, from, target, to) {
+				continue
+			}
+			foreignKeys = append(foreignKeys, &ast.AlterTable{
+				Name:            name,
+				TableAlteration: &ast.AddTableConstraint{TableConstraint: g.foreignKey(src.schema.TableName, typeName, rel, tableMap)},
+			})
+		}
+	}
+
+	for _, t := range l.tables {
+		if !inSchema[strings.ToLower(t.schema.TableName)] {
+			m.Review = append(m.Review, Drift{
+				Kind:    DriftExtraTable,
+				Table:   t.schema.TableName,
+				Message: "table is not in the schema, DROP TABLE needs manual review",
+			})
+		}
+	}
+
+	// New tables are created first, with foreign keys inline when the target already exists
+	existing := make(map[string]bool)
+	for _, t := range l.tables {
+		existing[strings.ToLower(t.schema.TableName)] = true
+	}
+	ordered, err := orderByParent(newTables, existing)
+	if err != nil {
+		return Migration{}, err
+	}
+	var deferred []ast.DDL
+	for _, src := range ordered {
+		typeName := g.getTypeName(src.schema)
+		ct, err := g.createTable(src, tableMap)
+		if err != nil {
+			return Migration{}, fmt.Errorf("table %s: %w", src.schema.TableName, err)
+		}
+		existing[strings.ToLower(src.schema.TableName)] = true
+
+		for _, rel := range src.relations {
+			if g.isParentRelation(src.schema, typeName, rel, tableMap) {
+				continue
+			}
+			fk := g.foreignKey(src.schema.TableName, typeName, rel, tableMap)
+			if existing[strings.ToLower(g.tableName(tableMap, rel.Target))] {
+				ct.TableConstraints = append(ct.TableConstraints, fk)
+				continue
+			}
+			deferred = append(deferred, &ast.AlterTable{
+				Name:            ct.Name,
+				TableAlteration: &ast.AddTableConstraint{TableConstraint: fk},
+			})
+		}
+
+		creates = append(creates, ct)
+		for _, index := range src.schema.Indexes {
+			indexes = append(indexes, createIndex(src.schema.TableName, index))
+		}
+	}
+
+	m.Statements = append(m.Statements, creates...)
+	m.Statements = append(m.Statements, columns...)
+	m.Statements = append(m.Statements, indexes...)
+	m.Statements = append(m.Statements, deferred...)
+	m.Statements = append(m.Statements, foreignKeys...)
+	return m, nil
+}
+
+// diffColumns builds the column changes of an existing table
+// New columns are added as nullable since existing rows have no value for them, and
+// dropping NOT NULL is emitted as ALTER COLUMN. Other differences are returned for review
+func diffColumns(name *ast.Path, cols []columnInfo, t *ddlTable) ([]ast.DDL, []Drift, error) {
+	var stmts []ast.DDL
+	var review []Drift
+	inModel := make(map[string]bool)
+	for _, col := range cols {
+		inModel[strings.ToLower(col.ColumnName)] = true
+		def := t.column(col.ColumnName)
+		if def == nil {
+			cd, err := columnDef(col)
+			if err != nil {
+				return nil, nil, err
+			}
+			if cd.NotNull {
+				cd.NotNull = false
+				review = append(review, Drift{
+					Kind:    DriftNullability,
+					Table:   pathName(name),
+					Column:  col.ColumnName,
+					Message: fmt.Sprintf("column is added as nullable, backfill it and then ALTER COLUMN %s %s NOT NULL", col.ColumnName, cd.Type.SQL()),
+				})
+			}
+			stmts = append(stmts, &ast.AlterTable{
+				Name:            name,
+				TableAlteration: &ast.AddColumn{Column: cd},
+			})
+			continue
+		}
+
+		if col.SpannerType != "" && !spannerTypesMatch(col.SpannerType, def.SpannerType) {
+			review = append(review, Drift{
+				Kind:    DriftTypeMismatch,
+				Table:   pathName(name),
+				Column:  col.ColumnName,
+				Message: fmt.Sprintf("changing the type from %s to %s needs manual review", def.SpannerType, col.SpannerType),
+			})
+			continue
+		}
+
+		switch {
+		case col.NotNull && !def.NotNull:
+			review = append(review, Drift{
+				Kind:    DriftNullability,
+				Table:   pathName(name),
+				Column:  col.ColumnName,
+				Message: "adding NOT NULL needs manual review, existing rows may hold NULL",
+			})
+		case !col.NotNull && def.NotNull:
+			schemaType, err := parseSpannerType(def.SpannerType)
+			if err != nil {
+				return nil, nil, fmt.Errorf("column %s: %w", col.ColumnName, err)
+			}
+			stmts = append(stmts, &ast.AlterTable{
+				Name: name,
+				TableAlteration: &ast.AlterColumn{
+					Name:       &ast.Ident{Name: def.ColumnName},
+					Alteration: &ast.AlterColumnType{Type: schemaType, Null: token.InvalidPos},
+				},
+			})
+		}
+	}
+
+	for _, def := range t.schema.Columns {
+		if !inModel[strings.ToLower(def.ColumnName)] {
+			review = append(review, Drift{
+				Kind:    DriftExtraColumn,
+				Table:   pathName(name),
+				Column:  def.ColumnName,
+				Message: "column is not in the schema, DROP COLUMN needs manual review",
+			})
+		}
+	}
+	return stmts, review, nil
+}
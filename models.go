@@ -0,0 +1,127 @@
+package plate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// nullableGoTypes maps Go types of nullable columns to the Spanner client types that can hold NULL
+var nullableGoTypes = map[string]string{
+	"string":     "spanner.NullString",
+	"int64":      "spanner.NullInt64",
+	"float32":    "spanner.NullFloat32",
+	"float64":    "spanner.NullFloat64",
+	"bool":       "spanner.NullBool",
+	"time.Time":  "spanner.NullTime",
+	"civil.Date": "spanner.NullDate",
+	"big.Rat":    "spanner.NullNumeric",
+}
+
+// modelTemplateData represents a model struct for templates
+type modelTemplateData struct {
+	TypeName  string
+	TableName string
+	Fields    []columnInfo
+}
+
+// GenerateModelFiles generates model structs with spanner and spannerType tags for the tables
+// of the schema that have no Model, such as tables loaded from DDL
+func (g *Generator) GenerateModelFiles(schema Schema) (GeneratedFiles, error) {
+	tmpl, err := getTemplates()
+	if err != nil {
+		return GeneratedFiles{}, err
+	}
+
+	var models []modelTemplateData
+	var fields []columnInfo
+	addModel := func(ts TableSchema) {
+		if ts.Model != nil {
+			return
+		}
+		model := modelTemplateData{
+			TypeName:  ts.TypeName,
+			TableName: ts.TableName,
+		}
+		for _, col := range ts.Columns {
+			model.Fields = append(model.Fields, columnInfo{
+				Name:        col.Name,
+				GoType:      modelFieldType(col),
+				Imports:     col.Imports,
+				SpannerType: col.SpannerType,
+				ColumnName:  col.ColumnName,
+			})
+		}
+		models = append(models, model)
+		fields = append(fields, model.Fields...)
+	}
+	for _, tc := range schema.Tables {
+		addModel(tc.Schema)
+	}
+	for _, jc := range schema.Junctions {
+		addModel(jc.Schema)
+	}
+
+	code, err := renderTemplate(tmpl, "models", templateData{
+		Models:  models,
+		Imports: columnImports(fields),
+	})
+	if err != nil {
+		return GeneratedFiles{}, fmt.Errorf("failed to generate models: %w", err)
+	}
+
+	return GeneratedFiles{Files: map[string]string{"models/models.go": code}}, nil
+}
+
+// withModelFieldTypes returns the schema with the Go types of columns of tables without a Model
+// replaced by their model field types, so accessors take the same values as the generated models
+func withModelFieldTypes(schema Schema) Schema {
+	result := Schema{
+		Tables:    make([]TableConfig, len(schema.Tables)),
+		Junctions: make([]JunctionConfig, len(schema.Junctions)),
+	}
+	for i, tc := range schema.Tables {
+		tc.Schema = modelSchema(tc.Schema)
+		result.Tables[i] = tc
+	}
+	for i, jc := range schema.Junctions {
+		jc.Schema = modelSchema(jc.Schema)
+		result.Junctions[i] = jc
+	}
+	return result
+}
+
+// modelSchema returns a copy of the table whose column Go types are the model field types
+func modelSchema(ts TableSchema) TableSchema {
+	if ts.Model != nil {
+		return ts
+	}
+	columns := make([]ColumnSchema, len(ts.Columns))
+	for i, col := range ts.Columns {
+		col.GoType = modelFieldType(col)
+		columns[i] = col
+	}
+	ts.Columns = columns
+	return ts
+}
+
+// modelFieldType returns the Go type of a model field for the column
+// Nullable columns use the Spanner client's Null* types, and array elements are always nullable
+// Types that already hold NULL are returned as is, so it can be applied more than once
+func modelFieldType(col ColumnSchema) string {
+	if elem, ok := strings.CutPrefix(col.GoType, "[]"); ok && elem != "byte" {
+		return "[]" + nullableGoType(elem)
+	}
+	if col.NotNull {
+		return col.GoType
+	}
+	return nullableGoType(col.GoType)
+}
+
+// nullableGoType returns the Go type that can hold NULL for values of the given type
+// Types that can already hold NULL, such as []byte and spanner.NullJSON, are returned as is
+func nullableGoType(goType string) string {
+	if nullable, ok := nullableGoTypes[goType]; ok {
+		return nullable
+	}
+	return goType
+}
@@ -0,0 +1,82 @@
+package query
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/rail44/plate/types"
+)
+
+// CaseBuilder builds a CASE expression on table T whose branches evaluate to values of type V
+// Finish it with Else or End to get an expression for projection, ORDER BY and comparisons
+type CaseBuilder[T types.Table, V any] struct {
+	whens []caseBranch[T]
+}
+
+// caseBranch is a WHEN condition with the expression of its THEN branch
+type caseBranch[T types.Table] struct {
+	cond types.ExprOption[T]
+	then func(s *types.State) ast.Expr
+}
+
+// Case starts a CASE expression with its first WHEN condition and THEN value
+// Generates: CASE WHEN cond THEN @p0 ... END
+func Case[T types.Table, V any](cond types.ExprOption[T], value V) CaseBuilder[T, V] {
+	return CaseBuilder[T, V]{}.When(cond, value)
+}
+
+// CaseColumn starts a CASE expression whose first THEN branch is a column or expression
+func CaseColumn[T types.Table, V any](cond types.ExprOption[T], then types.Operand[T, V]) CaseBuilder[T, V] {
+	return CaseBuilder[T, V]{}.WhenColumn(cond, then)
+}
+
+// When adds a WHEN condition with a THEN value
+func (b CaseBuilder[T, V]) When(cond types.ExprOption[T], value V) CaseBuilder[T, V] {
+	return b.with(cond, types.Param[T](value).BuildExpr)
+}
+
+// WhenColumn adds a WHEN condition whose THEN branch is a column or expression
+func (b CaseBuilder[T, V]) WhenColumn(cond types.ExprOption[T], then types.Operand[T, V]) CaseBuilder[T, V] {
+	return b.with(cond, then.BuildExpr)
+}
+
+// with returns a copy of the builder with the branch added
+func (b CaseBuilder[T, V]) with(cond types.ExprOption[T], then func(s *types.State) ast.Expr) CaseBuilder[T, V] {
+	whens := append(b.whens[:len(b.whens):len(b.whens)], caseBranch[T]{cond: cond, then: then})
+	return CaseBuilder[T, V]{whens: whens}
+}
+
+// Else finishes the CASE expression with an ELSE value
+func (b CaseBuilder[T, V]) Else(value V) types.Expr[T, V] {
+	return b.build(types.Param[T](value).BuildExpr)
+}
+
+// ElseColumn finishes the CASE expression with a column or expression in the ELSE branch
+func (b CaseBuilder[T, V]) ElseColumn(other types.Operand[T, V]) types.Expr[T, V] {
+	return b.build(other.BuildExpr)
+}
+
+// End finishes the CASE expression without ELSE, so it evaluates to NULL when no condition holds
+func (b CaseBuilder[T, V]) End() types.Expr[T, V] {
+	return b.build(nil)
+}
+
+// build creates the CASE expression, adding parameters in the order they appear in the SQL
+func (b CaseBuilder[T, V]) build(elseExpr func(s *types.State) ast.Expr) types.Expr[T, V] {
+	return types.NewExpr[T, V](func(s *types.State) ast.Expr {
+		expr := &ast.CaseExpr{}
+		for _, when := range b.whens {
+			var cond ast.Expr
+			when.cond(s, &cond)
+			if cond == nil {
+				cond = &ast.BoolLiteral{Value: true}
+			}
+			expr.Whens = append(expr.Whens, &ast.CaseWhen{
+				Cond: cond,
+				Then: when.then(s),
+			})
+		}
+		if elseExpr != nil {
+			expr.Else = &ast.CaseElse{Expr: elseExpr(s)}
+		}
+		return expr
+	})
+}
@@ -0,0 +1,36 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/rail44/plate/types"
+)
+
+// Delete is a generic delete function for any table
+// Spanner requires a WHERE clause, so at least one condition must be given;
+// use AllRows to delete every row explicitly
+func Delete[T types.Table](opts ...types.DeleteOption[T]) (string, []any, error) {
+	var t T
+	tableName := t.TableName()
+	s := newState(tableName)
+
+	stmt := &ast.Delete{
+		TableName: &ast.Path{
+			Idents: []*ast.Ident{
+				{Name: tableName},
+			},
+		},
+	}
+
+	// Apply all options
+	for _, opt := range opts {
+		opt.ApplyDelete(s, stmt)
+	}
+
+	if stmt.Where == nil {
+		return "", nil, fmt.Errorf("delete from %s: no WHERE condition given (use AllRows to delete every row)", tableName)
+	}
+
+	return stmt.SQL(), s.Params, nil
+}
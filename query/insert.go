@@ -0,0 +1,113 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/rail44/plate/types"
+)
+
+// Insert is a generic insert function for any table
+// Rows are added with Values, and all rows share the same column list
+func Insert[T types.Table](opts ...types.InsertOption[T]) (string, []any, error) {
+	var t T
+	tableName := t.TableName()
+	s := newState(tableName)
+
+	input := &ast.ValuesInput{}
+	stmt := &ast.Insert{
+		TableName: &ast.Path{
+			Idents: []*ast.Ident{
+				{Name: tableName},
+			},
+		},
+		Input: input,
+	}
+
+	// Apply all options
+	for _, opt := range opts {
+		opt.ApplyInsert(s, stmt)
+	}
+
+	if len(input.Rows) == 0 || len(stmt.Columns) == 0 {
+		return "", nil, fmt.Errorf("insert into %s: no values given", tableName)
+	}
+
+	// INSERT OR UPDATE writes DEFAULT over existing values, so every row must set the same columns
+	if stmt.InsertOrType == ast.InsertOrTypeUpdate && hasDefaultValues(input.Rows) {
+		return "", nil, fmt.Errorf("insert or update into %s: rows set different columns, missing columns would be reset to their defaults", tableName)
+	}
+
+	return stmt.SQL(), s.Params, nil
+}
+
+// Values adds a row to an INSERT statement
+// Columns that are set in other rows but not in this one are filled with DEFAULT,
+// which Insert rejects for INSERT OR UPDATE
+func Values[T types.Table](values ...types.Assignment[T]) types.InsertClause[T] {
+	return func(s *types.State, stmt *ast.Insert) {
+		input := stmt.Input.(*ast.ValuesInput)
+
+		row := &ast.ValuesRow{}
+		for range stmt.Columns {
+			row.Exprs = append(row.Exprs, &ast.DefaultExpr{Default: true})
+		}
+
+		for _, value := range values {
+			idx := columnIndex(stmt.Columns, value.Column)
+			if idx < 0 {
+				// New column: extend the column list and backfill previous rows
+				stmt.Columns = append(stmt.Columns, &ast.Ident{Name: value.Column})
+				for _, r := range input.Rows {
+					r.Exprs = append(r.Exprs, &ast.DefaultExpr{Default: true})
+				}
+				row.Exprs = append(row.Exprs, &ast.DefaultExpr{Default: true})
+				idx = len(stmt.Columns) - 1
+			}
+
+			i := len(s.Params)
+			s.Params = append(s.Params, value.Value)
+			row.Exprs[idx] = &ast.DefaultExpr{
+				Expr: &ast.Param{Name: fmt.Sprintf("p%d", i)},
+			}
+		}
+
+		input.Rows = append(input.Rows, row)
+	}
+}
+
+// OrUpdate turns the statement into INSERT OR UPDATE
+func OrUpdate[T types.Table]() types.InsertClause[T] {
+	return func(s *types.State, stmt *ast.Insert) {
+		stmt.InsertOrType = ast.InsertOrTypeUpdate
+	}
+}
+
+// OrIgnore turns the statement into INSERT OR IGNORE
+func OrIgnore[T types.Table]() types.InsertClause[T] {
+	return func(s *types.State, stmt *ast.Insert) {
+		stmt.InsertOrType = ast.InsertOrTypeIgnore
+	}
+}
+
+// hasDefaultValues reports whether any row has a column filled with DEFAULT
+func hasDefaultValues(rows []*ast.ValuesRow) bool {
+	for _, row := range rows {
+		for _, expr := range row.Exprs {
+			if expr.Default {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// columnIndex returns the position of the column in the list, or -1 if absent
+func columnIndex(columns []*ast.Ident, name string) int {
+	for i, col := range columns {
+		if col.Name == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,159 @@
+package query
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/rail44/plate/types"
+)
+
+// Join adds a flat JOIN to a related table
+// Conditions from opts are added to the ON clause and are qualified by the join alias,
+// columns selected with Columns are added to the parent SELECT, and ORDER BY items are
+// carried over to the parent query
+// LIMIT, GROUP BY and HAVING belong to the parent query, so they are not accepted as opts
+// Generates: FROM base INNER JOIN target AS alias ON alias.to = base.from AND ...
+func Join[TBase types.Table, TTarget types.Table](
+	op ast.JoinOp,
+	alias string,
+	targetTable string,
+	keys KeyPair,
+	junctionTable string, // empty for direct relationships
+	junctionKeys KeyPair, // empty for direct relationships
+	opts ...types.JoinOption[TTarget],
+) types.QueryOption[TBase] {
+	return func(s *types.State, q *ast.Query) {
+		sl := q.Query.(*ast.Select)
+		baseAlias := s.CurrentAlias()
+
+		// Introduce the junction table first, as it precedes the target in the FROM clause
+		junctionAlias := ""
+		if junctionTable != "" {
+			junctionAlias = s.UniqueAlias(junctionTable)
+			s.Tables[junctionAlias] = struct{}{}
+		}
+		joinState := s.NewJoinState(targetTable, alias)
+		alias = joinState.CurrentAlias()
+
+		// Apply options to a temporary query scoped to the joined table
+		tempQuery := &ast.Query{
+			Query: &ast.Select{
+				Results: []ast.SelectItem{
+					&ast.DotStar{
+						Expr: &ast.Path{
+							Idents: []*ast.Ident{{Name: alias}},
+						},
+					},
+				},
+				From: &ast.From{
+					Source: joinTableName(targetTable, alias),
+				},
+			},
+		}
+		for _, opt := range opts {
+			opt.Apply(joinState, tempQuery)
+		}
+		s.Params = joinState.Params
+		tempSelect := tempQuery.Query.(*ast.Select)
+
+		// Build the ON condition
+		source := sl.From.Source
+		var cond ast.Expr
+		if junctionTable == "" {
+			cond = columnEquals(alias, keys.To, baseAlias, keys.From)
+		} else {
+			source = &ast.Join{
+				Op:    op,
+				Left:  source,
+				Right: joinTableName(junctionTable, junctionAlias),
+				Cond: &ast.On{
+					Expr: columnEquals(junctionAlias, keys.To, baseAlias, keys.From),
+				},
+			}
+			cond = columnEquals(alias, junctionKeys.To, junctionAlias, junctionKeys.From)
+		}
+		if tempSelect.Where != nil {
+			cond = &ast.BinaryExpr{
+				Op:    ast.OpAnd,
+				Left:  cond,
+				Right: tempSelect.Where.Expr,
+			}
+		}
+
+		sl.From.Source = &ast.Join{
+			Op:    op,
+			Left:  source,
+			Right: joinSource(tempSelect.From.Source),
+			Cond:  &ast.On{Expr: cond},
+		}
+
+		// Qualify a bare star, as in relationship subqueries, so that it keeps selecting only the base table
+		for i, item := range sl.Results {
+			if _, isStar := item.(*ast.Star); isStar {
+				sl.Results[i] = &ast.DotStar{
+					Expr: &ast.Path{
+						Idents: []*ast.Ident{{Name: baseAlias}},
+					},
+				}
+			}
+		}
+
+		// Project joined columns selected with Columns
+		for _, item := range tempSelect.Results {
+			if _, isStar := item.(*ast.DotStar); isStar {
+				continue
+			}
+			sl.Results = append(sl.Results, item)
+		}
+
+		// Carry ORDER BY over to the parent query
+		if tempQuery.OrderBy != nil {
+			if q.OrderBy == nil {
+				q.OrderBy = &ast.OrderBy{}
+			}
+			q.OrderBy.Items = append(q.OrderBy.Items, tempQuery.OrderBy.Items...)
+		}
+
+		// Relationship subqueries on the joined table are added to the parent SELECT
+		s.SubqueryColumns = append(s.SubqueryColumns, joinState.SubqueryColumns...)
+	}
+}
+
+// joinSource returns the right side of a join, grouping joins nested in it with parentheses
+// so that they are joined to the target table before it is joined to the parent
+func joinSource(source ast.TableExpr) ast.TableExpr {
+	if _, nested := source.(*ast.Join); nested {
+		return &ast.ParenTableExpr{Source: source}
+	}
+	return source
+}
+
+// joinTableName creates a table reference with an alias when it differs from the table name
+func joinTableName(table, alias string) *ast.TableName {
+	tn := &ast.TableName{
+		Table: &ast.Ident{Name: table},
+	}
+	if alias != table {
+		tn.As = &ast.AsAlias{
+			Alias: &ast.Ident{Name: alias},
+		}
+	}
+	return tn
+}
+
+// columnEquals creates an equality condition between two qualified columns
+func columnEquals(leftTable, leftColumn, rightTable, rightColumn string) ast.Expr {
+	return &ast.BinaryExpr{
+		Left: &ast.Path{
+			Idents: []*ast.Ident{
+				{Name: leftTable},
+				{Name: leftColumn},
+			},
+		},
+		Op: ast.OpEqual,
+		Right: &ast.Path{
+			Idents: []*ast.Ident{
+				{Name: rightTable},
+				{Name: rightColumn},
+			},
+		},
+	}
+}
@@ -43,11 +43,8 @@ func Or[T types.Table](opts ...types.ExprOption[T]) types.ExprOption[T] {
 	return logicalOp(ast.OpOr, opts...)
 }
 
-// Select is a generic select function for any table
-func Select[T types.Table](opts ...types.Option[T]) (string, []any) {
-	var t T
-	tableName := t.TableName()
-
+// newState creates a new state for a statement on the given table
+func newState(tableName string) *types.State {
 	s := &types.State{
 		Tables:          make(map[string]struct{}),
 		Params:          []any{},
@@ -55,6 +52,38 @@ func Select[T types.Table](opts ...types.Option[T]) (string, []any) {
 		SubqueryColumns: []types.SubqueryColumn{},
 	}
 	s.Tables[tableName] = struct{}{}
+	return s
+}
+
+// Statement is a built query ready to be executed
+// Params is keyed by parameter name (p0, p1, ...) as expected by spanner.Statement
+type Statement struct {
+	SQL    string
+	Params map[string]any
+	Query  *ast.Query
+}
+
+// Select is a generic select function for any table
+func Select[T types.Table](opts ...types.Option[T]) (string, []any) {
+	q, s := buildSelect(opts...)
+	return q.SQL(), s.Params
+}
+
+// Build is a generic select function for any table that returns a Statement
+func Build[T types.Table](opts ...types.Option[T]) Statement {
+	q, s := buildSelect(opts...)
+	return Statement{
+		SQL:    q.SQL(),
+		Params: s.NamedParams(),
+		Query:  q,
+	}
+}
+
+// buildSelect builds the SELECT query AST and returns it with the final state
+func buildSelect[T types.Table](opts ...types.Option[T]) (*ast.Query, *types.State) {
+	var t T
+	tableName := t.TableName()
+	s := newState(tableName)
 
 	// Start with table.* instead of just *
 	stmt := ast.Select{
@@ -86,18 +115,22 @@ func Select[T types.Table](opts ...types.Option[T]) (string, []any) {
 	}
 
 	// Add subquery columns to SELECT
-	if len(s.SubqueryColumns) > 0 {
-		for _, col := range s.SubqueryColumns {
-			stmt.Results = append(stmt.Results, &ast.Alias{
-				Expr: col.Subquery,
-				As: &ast.AsAlias{
-					Alias: &ast.Ident{Name: col.Alias},
-				},
-			})
-		}
-	}
+	stmt.Results = appendSubqueryColumns(stmt.Results, s.SubqueryColumns)
 
-	return q.SQL(), s.Params
+	return q, s
+}
+
+// appendSubqueryColumns adds subquery columns to a SELECT list as aliased items
+func appendSubqueryColumns(results []ast.SelectItem, cols []types.SubqueryColumn) []ast.SelectItem {
+	for _, col := range cols {
+		results = append(results, &ast.Alias{
+			Expr: col.Subquery,
+			As: &ast.AsAlias{
+				Alias: &ast.Ident{Name: col.Alias},
+			},
+		})
+	}
+	return results
 }
 
 // KeyPair represents a relationship between two tables through their keys
@@ -116,7 +149,8 @@ func convertOptions[T types.Table](opts []types.Option[T]) []types.Option[types.
 }
 
 // OrderBy creates an ORDER BY clause for any table type
-func OrderBy[T types.Table, V any](column types.Column[T, V], dir ast.Direction) types.QueryOption[T] {
+// Any expression of the table can be used, including columns and aggregates
+func OrderBy[T types.Table](expr types.Expression[T], dir ast.Direction) types.QueryOption[T] {
 	return func(s *types.State, q *ast.Query) {
 		if q.OrderBy == nil {
 			q.OrderBy = &ast.OrderBy{
@@ -124,17 +158,88 @@ func OrderBy[T types.Table, V any](column types.Column[T, V], dir ast.Direction)
 			}
 		}
 		q.OrderBy.Items = append(q.OrderBy.Items, &ast.OrderByItem{
-			Expr: &ast.Path{
-				Idents: []*ast.Ident{
-					{Name: s.CurrentAlias()},
-					{Name: column.Name},
-				},
-			},
-			Dir: dir,
+			Expr: expr.BuildExpr(s),
+			Dir:  dir,
 		})
 	}
 }
 
+// GroupBy creates a GROUP BY clause for any table type
+func GroupBy[T types.Table](exprs ...types.Expression[T]) types.QueryClause[T] {
+	return func(s *types.State, q *ast.Query) {
+		sl := q.Query.(*ast.Select)
+		if sl.GroupBy == nil {
+			sl.GroupBy = &ast.GroupBy{}
+		}
+		for _, expr := range exprs {
+			sl.GroupBy.Exprs = append(sl.GroupBy.Exprs, expr.BuildExpr(s))
+		}
+	}
+}
+
+// Having creates a HAVING clause from conditions, typically over aggregates
+// Multiple conditions are combined with AND
+func Having[T types.Table](opts ...types.ExprOption[T]) types.QueryClause[T] {
+	return func(s *types.State, q *ast.Query) {
+		sl := q.Query.(*ast.Select)
+		for _, opt := range opts {
+			var expr ast.Expr
+			opt(s, &expr)
+			if expr == nil {
+				continue
+			}
+
+			if sl.Having == nil {
+				sl.Having = &ast.Having{Expr: expr}
+			} else {
+				sl.Having.Expr = &ast.BinaryExpr{
+					Op:    ast.OpAnd,
+					Left:  sl.Having.Expr,
+					Right: expr,
+				}
+			}
+		}
+	}
+}
+
+// Columns replaces the star projection with the given columns or expressions
+// It can be used in the main query and inside relationship subqueries
+// Without columns the star projection is kept
+func Columns[T types.Table](columns ...types.Selectable[T]) types.QueryOption[T] {
+	return func(s *types.State, q *ast.Query) {
+		if len(columns) == 0 {
+			return
+		}
+		sl := q.Query.(*ast.Select)
+
+		// Drop star items, keep everything else (e.g. subquery columns)
+		var results []ast.SelectItem
+		for _, item := range sl.Results {
+			switch item.(type) {
+			case *ast.Star, *ast.DotStar:
+				continue
+			}
+			results = append(results, item)
+		}
+
+		for _, col := range columns {
+			results = append(results, col.SelectItem(s))
+		}
+		sl.Results = results
+	}
+}
+
+// AddColumns appends columns or expressions to the SELECT list, keeping the star projection
+// It is typically used for computed items named with As
+func AddColumns[T types.Table](columns ...types.Selectable[T]) types.QueryOption[T] {
+	return func(s *types.State, q *ast.Query) {
+		sl := q.Query.(*ast.Select)
+		for _, col := range columns {
+			sl.Results = append(sl.Results, col.SelectItem(s))
+		}
+	}
+}
+
 // Not creates a logical NOT condition that wraps any ExprOption
 // This allows negation of complex expressions including And() and Or() combinations
 func Not[T types.Table](opt types.ExprOption[T]) types.ExprOption[T] {
@@ -162,8 +267,16 @@ func Not[T types.Table](opt types.ExprOption[T]) types.ExprOption[T] {
 	}
 }
 
+// AllRows creates an always-true condition (WHERE TRUE)
+// DML statements require it to explicitly affect every row of the table
+func AllRows[T types.Table]() types.ExprOption[T] {
+	return func(s *types.State, expr *ast.Expr) {
+		*expr = &ast.BoolLiteral{Value: true}
+	}
+}
+
 // Limit creates a LIMIT clause for any table type
-func Limit[T types.Table](count int) types.QueryOption[T] {
+func Limit[T types.Table](count int) types.QueryClause[T] {
 	return func(s *types.State, q *ast.Query) {
 		q.Limit = &ast.Limit{
 			Count: &ast.IntLiteral{
@@ -194,9 +307,11 @@ func WithOne[TBase types.Table, TTarget types.Table](
 			Query: &ast.Query{
 				Query: &ast.Select{
 					As:      &ast.AsStruct{},
-					Results: subSelect.Results,
+					Results: sq.results(subSelect.Results),
 					From:    subSelect.From,
 					Where:   subSelect.Where,
+					GroupBy: subSelect.GroupBy,
+					Having:  subSelect.Having,
 				},
 			},
 		}
@@ -206,7 +321,8 @@ func WithOne[TBase types.Table, TTarget types.Table](
 }
 
 // WithMany adds an array subquery column (for has_many relationships)
-// Generates: ARRAY(SELECT AS STRUCT t.* FROM t WHERE t.foreign_key = parent.id)
+// ORDER BY, LIMIT, GROUP BY and HAVING from opts are applied inside the array, e.g. to load the latest N children
+// Generates: ARRAY(SELECT AS STRUCT t.* FROM t WHERE t.foreign_key = parent.id [ORDER BY ...] [LIMIT n])
 func WithMany[TBase types.Table, TTarget types.Table](
 	relationshipName string,
 	targetTable string,
@@ -224,19 +340,15 @@ func WithMany[TBase types.Table, TTarget types.Table](
 		subSelect := subQuery.Query.(*ast.Select)
 		structSelect := &ast.Select{
 			As:      &ast.AsStruct{},
-			Results: []ast.SelectItem{&ast.Star{}},
-			From: &ast.From{
-				Source: &ast.TableName{
-					Table: &ast.Ident{Name: targetTable},
-				},
-			},
-			Where: subSelect.Where,
+			Results: sq.results(subSelect.Results),
+			From:    subSelect.From,
+			Where:   subSelect.Where,
+			GroupBy: subSelect.GroupBy,
+			Having:  subSelect.Having,
 		}
 
 		subqueryExpr := &ast.ArraySubQuery{
-			Query: &ast.Query{
-				Query: structSelect,
-			},
+			Query: sq.structQuery(structSelect, subQuery),
 		}
 
 		sq.addSubqueryColumn(s, relationshipName, subqueryExpr)
@@ -244,7 +356,8 @@ func WithMany[TBase types.Table, TTarget types.Table](
 }
 
 // WithManyThrough adds an array subquery column (for many_to_many relationships through a junction table)
-// Generates: ARRAY(SELECT AS STRUCT t.* FROM t JOIN junction ON ... WHERE junction.foreign_key = parent.id)
+// ORDER BY, LIMIT, GROUP BY and HAVING from opts are applied inside the array as with WithMany
+// Generates: ARRAY(SELECT AS STRUCT t.* FROM t JOIN junction ON ... WHERE junction.foreign_key = parent.id [ORDER BY ...] [LIMIT n])
 func WithManyThrough[TBase types.Table, TTarget types.Table](
 	relationshipName string,
 	targetTable string,
@@ -255,16 +368,21 @@ func WithManyThrough[TBase types.Table, TTarget types.Table](
 ) types.QueryOption[TBase] {
 	return func(s *types.State, q *ast.Query) {
 		sq := newSubquery(s, targetTable, keys)
+		junctionAlias := sq.junctionAlias(junctionTable)
 
 		// Create a temporary query just for applying options
 		tempQuery := &ast.Query{
 			Query: &ast.Select{
-				Results: []ast.SelectItem{&ast.Star{}},
-				From: &ast.From{
-					Source: &ast.TableName{
-						Table: &ast.Ident{Name: targetTable},
+				Results: []ast.SelectItem{
+					&ast.DotStar{
+						Expr: &ast.Path{
+							Idents: []*ast.Ident{{Name: sq.targetAlias}},
+						},
 					},
 				},
+				From: &ast.From{
+					Source: sq.targetTableName(),
+				},
 			},
 		}
 		sq.applyOptions(tempQuery, convertOptions(opts))
@@ -278,20 +396,16 @@ func WithManyThrough[TBase types.Table, TTarget types.Table](
 
 		// Create many-to-many array subquery with JOIN
 		structSelect := &ast.Select{
-			As: &ast.AsStruct{},
-			Results: []ast.SelectItem{
-				&ast.DotStar{
-					Expr: &ast.Path{
-						Idents: []*ast.Ident{{Name: targetTable}},
-					},
-				},
-			},
+			As:      &ast.AsStruct{},
+			Results: sq.results(tempSelect.Results),
 			From: &ast.From{
-				Source: sq.buildJunctionJoin(junctionTable, junctionKeys),
+				Source: sq.buildJunctionJoin(tempSelect.From.Source, junctionTable, junctionAlias, junctionKeys),
 			},
 			Where: &ast.Where{
-				Expr: sq.buildJunctionCorrelation(junctionTable),
+				Expr: sq.buildJunctionCorrelation(junctionAlias),
 			},
+			GroupBy: tempSelect.GroupBy,
+			Having:  tempSelect.Having,
 		}
 
 		// Apply additional WHERE conditions from options
@@ -304,9 +418,7 @@ func WithManyThrough[TBase types.Table, TTarget types.Table](
 		}
 
 		subqueryExpr := &ast.ArraySubQuery{
-			Query: &ast.Query{
-				Query: structSelect,
-			},
+			Query: sq.structQuery(structSelect, tempQuery),
 		}
 
 		sq.addSubqueryColumn(s, relationshipName, subqueryExpr)
@@ -338,14 +450,15 @@ func WhereExists[TBase types.Table, TTarget types.Table](
 			subQuery = sq.buildBasicSubquery(selectItems)
 		} else {
 			// Junction relationship - need to handle differently
+			junctionAlias := sq.junctionAlias(junctionTable)
 			subQuery = &ast.Query{
 				Query: &ast.Select{
 					Results: selectItems,
 					From: &ast.From{
-						Source: sq.buildJunctionJoin(junctionTable, junctionKeys),
+						Source: sq.buildJunctionJoin(sq.targetTableName(), junctionTable, junctionAlias, junctionKeys),
 					},
 					Where: &ast.Where{
-						Expr: sq.buildJunctionCorrelation(junctionTable),
+						Expr: sq.buildJunctionCorrelation(junctionAlias),
 					},
 				},
 			}
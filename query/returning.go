@@ -0,0 +1,28 @@
+package query
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/rail44/plate/types"
+)
+
+// Returning creates a THEN RETURN clause for INSERT, UPDATE and DELETE statements
+// Without columns it returns every column (THEN RETURN *), matching the model struct
+func Returning[T types.Table](columns ...types.ColumnRef[T]) types.ReturningOption[T] {
+	return func(s *types.State) *ast.ThenReturn {
+		if len(columns) == 0 {
+			return &ast.ThenReturn{
+				Items: []ast.SelectItem{&ast.Star{}},
+			}
+		}
+
+		items := make([]ast.SelectItem, len(columns))
+		for i, col := range columns {
+			items[i] = &ast.ExprSelectItem{
+				Expr: &ast.Ident{Name: col.ColumnName()},
+			}
+		}
+		return &ast.ThenReturn{
+			Items: items,
+		}
+	}
+}
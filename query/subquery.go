@@ -11,6 +11,7 @@ type subquery struct {
 	subState    *types.State
 	baseAlias   string
 	targetTable string
+	targetAlias string
 	keys        KeyPair
 }
 
@@ -23,54 +24,36 @@ func newSubquery(parentState *types.State, targetTable string, keys KeyPair) *su
 		keys:        keys,
 	}
 	sq.subState = parentState.NewSubqueryState(targetTable)
+	sq.targetAlias = sq.subState.CurrentAlias()
 	return sq
 }
 
+// targetTableName builds the FROM reference to the target table, aliased if necessary
+func (sq *subquery) targetTableName() *ast.TableName {
+	return joinTableName(sq.targetTable, sq.targetAlias)
+}
+
+// junctionAlias introduces the junction table in the subquery scope and returns its alias
+func (sq *subquery) junctionAlias(junctionTable string) string {
+	alias := sq.subState.UniqueAlias(junctionTable)
+	sq.subState.Tables[alias] = struct{}{}
+	return alias
+}
+
 // buildJunctionCorrelation builds the WHERE clause for junction table correlation
-func (sq *subquery) buildJunctionCorrelation(junctionTable string) ast.Expr {
-	return &ast.BinaryExpr{
-		Left: &ast.Path{
-			Idents: []*ast.Ident{
-				{Name: junctionTable},
-				{Name: sq.keys.To},
-			},
-		},
-		Op: ast.OpEqual,
-		Right: &ast.Path{
-			Idents: []*ast.Ident{
-				{Name: sq.baseAlias},
-				{Name: sq.keys.From},
-			},
-		},
-	}
+func (sq *subquery) buildJunctionCorrelation(junctionAlias string) ast.Expr {
+	return columnEquals(junctionAlias, sq.keys.To, sq.baseAlias, sq.keys.From)
 }
 
 // buildJunctionJoin builds the JOIN clause for junction tables
-func (sq *subquery) buildJunctionJoin(junctionTable string, junctionKeys KeyPair) *ast.Join {
+// source is the target table, possibly with flat joins added by options
+func (sq *subquery) buildJunctionJoin(source ast.TableExpr, junctionTable, junctionAlias string, junctionKeys KeyPair) *ast.Join {
 	return &ast.Join{
-		Op: ast.InnerJoin,
-		Left: &ast.TableName{
-			Table: &ast.Ident{Name: sq.targetTable},
-		},
-		Right: &ast.TableName{
-			Table: &ast.Ident{Name: junctionTable},
-		},
+		Op:    ast.InnerJoin,
+		Left:  source,
+		Right: joinTableName(junctionTable, junctionAlias),
 		Cond: &ast.On{
-			Expr: &ast.BinaryExpr{
-				Left: &ast.Path{
-					Idents: []*ast.Ident{
-						{Name: sq.targetTable},
-						{Name: junctionKeys.To},
-					},
-				},
-				Op: ast.OpEqual,
-				Right: &ast.Path{
-					Idents: []*ast.Ident{
-						{Name: junctionTable},
-						{Name: junctionKeys.From},
-					},
-				},
-			},
+			Expr: columnEquals(sq.targetAlias, junctionKeys.To, junctionAlias, junctionKeys.From),
 		},
 	}
 }
@@ -81,31 +64,14 @@ func (sq *subquery) buildBasicSubquery(selectItems []ast.SelectItem) *ast.Query
 		Query: &ast.Select{
 			Results: selectItems,
 			From: &ast.From{
-				Source: &ast.TableName{
-					Table: &ast.Ident{Name: sq.targetTable},
-				},
+				Source: sq.targetTableName(),
 			},
 		},
 	}
 
 	// Add WHERE clause for direct relationships
-	whereExpr := &ast.BinaryExpr{
-		Left: &ast.Path{
-			Idents: []*ast.Ident{
-				{Name: sq.targetTable},
-				{Name: sq.keys.To},
-			},
-		},
-		Op: ast.OpEqual,
-		Right: &ast.Path{
-			Idents: []*ast.Ident{
-				{Name: sq.baseAlias},
-				{Name: sq.keys.From},
-			},
-		},
-	}
 	query.Query.(*ast.Select).Where = &ast.Where{
-		Expr: whereExpr,
+		Expr: columnEquals(sq.targetAlias, sq.keys.To, sq.baseAlias, sq.keys.From),
 	}
 
 	return query
@@ -120,6 +86,22 @@ func (sq *subquery) applyOptions(subQuery *ast.Query, opts []types.Option[types.
 	sq.parentState.Params = sq.subState.Params
 }
 
+// results returns the STRUCT fields of the subquery, including nested relationship
+// subqueries added by options such as With* of the target table
+func (sq *subquery) results(items []ast.SelectItem) []ast.SelectItem {
+	return appendSubqueryColumns(items, sq.subState.SubqueryColumns)
+}
+
+// structQuery wraps the STRUCT select of a subquery, carrying over ORDER BY and LIMIT
+// set by options on the query they were applied to
+func (sq *subquery) structQuery(structSelect *ast.Select, optionQuery *ast.Query) *ast.Query {
+	return &ast.Query{
+		Query:   structSelect,
+		OrderBy: optionQuery.OrderBy,
+		Limit:   optionQuery.Limit,
+	}
+}
+
 // addSubqueryColumn adds a subquery column to the parent state
 func (sq *subquery) addSubqueryColumn(s *types.State, relationshipName string, subqueryExpr ast.Expr) {
 	s.SubqueryColumns = append(s.SubqueryColumns, types.SubqueryColumn{
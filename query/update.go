@@ -0,0 +1,54 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+	"github.com/rail44/plate/types"
+)
+
+// Update is a generic update function for any table
+// Spanner requires a WHERE clause, so at least one condition must be given;
+// use AllRows to update every row explicitly
+func Update[T types.Table](sets []types.Assignment[T], opts ...types.UpdateOption[T]) (string, []any, error) {
+	var t T
+	tableName := t.TableName()
+	s := newState(tableName)
+
+	if len(sets) == 0 {
+		return "", nil, fmt.Errorf("update %s: no assignments given", tableName)
+	}
+
+	stmt := &ast.Update{
+		TableName: &ast.Path{
+			Idents: []*ast.Ident{
+				{Name: tableName},
+			},
+		},
+	}
+
+	// Build SET clause
+	for _, set := range sets {
+		i := len(s.Params)
+		s.Params = append(s.Params, set.Value)
+		stmt.Updates = append(stmt.Updates, &ast.UpdateItem{
+			Path: []*ast.Ident{
+				{Name: set.Column},
+			},
+			DefaultExpr: &ast.DefaultExpr{
+				Expr: &ast.Param{Name: fmt.Sprintf("p%d", i)},
+			},
+		})
+	}
+
+	// Apply all options
+	for _, opt := range opts {
+		opt.ApplyUpdate(s, stmt)
+	}
+
+	if stmt.Where == nil {
+		return "", nil, fmt.Errorf("update %s: no WHERE condition given (use AllRows to update every row)", tableName)
+	}
+
+	return stmt.SQL(), s.Params, nil
+}
@@ -0,0 +1,200 @@
+package plate
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"reflect"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// LoadModels loads the model structs of a package from source, keyed by type name
+// dir is the directory the package pattern is resolved in. Every exported struct with at least
+// one spanner tag becomes a TableSchema with TypeName and Columns set, and field doc comments
+// are carried into the generated column accessors. TableName is left for the caller to set
+func LoadModels(dir, pattern string) (map[string]TableSchema, error) {
+	cfg := &packages.Config{
+		Mode: packages.NeedName | packages.NeedImports | packages.NeedDeps | packages.NeedTypes | packages.NeedSyntax,
+		Dir:  dir,
+	}
+	pkgs, err := packages.Load(cfg, pattern)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load models: %w", err)
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("models %s matched %d packages, want 1", pattern, len(pkgs))
+	}
+	pkg := pkgs[0]
+	if len(pkg.Errors) > 0 {
+		return nil, fmt.Errorf("failed to load models: %v", pkg.Errors[0])
+	}
+
+	docs := fieldDocs(pkg.Syntax)
+	models := make(map[string]TableSchema)
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		obj, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || !obj.Exported() {
+			continue
+		}
+		st, ok := obj.Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+		columns := structColumns(pkg.Fset, st, docs[name])
+		if len(columns) == 0 {
+			continue
+		}
+		models[name] = TableSchema{
+			TypeName: name,
+			Columns:  columns,
+			Junction: hasJunctionMarker(st),
+			Pos:      pkg.Fset.Position(obj.Pos()).String(),
+		}
+	}
+	return models, nil
+}
+
+// structColumns extracts the columns of a struct type, following extractColumns
+func structColumns(fset *token.FileSet, st *types.Struct, docs map[string]string) []ColumnSchema {
+	var columns []ColumnSchema
+	for i := 0; i < st.NumFields(); i++ {
+		field := st.Field(i)
+		if !field.Exported() {
+			continue
+		}
+		tag := reflect.StructTag(st.Tag(i))
+		spannerTag := tag.Get("spanner")
+		if spannerTag == "" {
+			continue
+		}
+		spannerType := tag.Get("spannerType")
+		if spannerType == "" {
+			spannerType = inferSourceSpannerType(field.Type())
+		}
+
+		columns = append(columns, ColumnSchema{
+			Name:        field.Name(),
+			ColumnName:  spannerTag,
+			SpannerType: spannerType,
+			GoType:      types.TypeString(field.Type(), packageName),
+			Imports:     sourceTypeImportPaths(field.Type()),
+			NotNull:     !isNullableSourceType(field.Type()),
+			Doc:         docs[field.Name()],
+			Relation:    tag.Get("plate"),
+			Pos:         fset.Position(field.Pos()).String(),
+		})
+	}
+	return columns
+}
+
+// hasJunctionMarker reports whether a struct type has the blank junction marker field
+func hasJunctionMarker(st *types.Struct) bool {
+	for i := 0; i < st.NumFields(); i++ {
+		if st.Field(i).Name() == "_" && reflect.StructTag(st.Tag(i)).Get("plate") == junctionTag {
+			return true
+		}
+	}
+	return false
+}
+
+// packageName qualifies types by package name, as in "time.Time"
+func packageName(pkg *types.Package) string {
+	return pkg.Name()
+}
+
+// sourceTypeImportPaths returns the import paths of the named types a Go type is built from,
+// following typeImportPaths
+func sourceTypeImportPaths(t types.Type) []string {
+	switch u := t.(type) {
+	case *types.Slice:
+		return sourceTypeImportPaths(u.Elem())
+	case *types.Array:
+		return sourceTypeImportPaths(u.Elem())
+	case *types.Pointer:
+		return sourceTypeImportPaths(u.Elem())
+	case *types.Named:
+		if pkg := u.Obj().Pkg(); pkg != nil {
+			return []string{pkg.Path()}
+		}
+	}
+	return nil
+}
+
+// isNullableSourceType reports whether values of the type can hold NULL, following isNullableType
+func isNullableSourceType(t types.Type) bool {
+	switch t.Underlying().(type) {
+	case *types.Pointer, *types.Slice, *types.Map, *types.Interface:
+		return true
+	}
+	named, ok := t.(*types.Named)
+	return ok && strings.HasPrefix(named.Obj().Name(), "Null")
+}
+
+// inferSourceSpannerType infers the Spanner type of a Go type, following inferSpannerType
+func inferSourceSpannerType(t types.Type) string {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		switch u.Kind() {
+		case types.String:
+			return "STRING"
+		case types.Int, types.Int64:
+			return "INT64"
+		case types.Float64:
+			return "FLOAT64"
+		case types.Bool:
+			return "BOOL"
+		}
+	case *types.Slice:
+		if elem, ok := u.Elem().Underlying().(*types.Basic); ok && elem.Kind() == types.Uint8 {
+			return "BYTES"
+		}
+		if elemType := inferSourceSpannerType(u.Elem()); elemType != "" {
+			return fmt.Sprintf("ARRAY<%s>", elemType)
+		}
+	}
+
+	switch types.TypeString(t, packageName) {
+	case "time.Time":
+		return "TIMESTAMP"
+	case "civil.Date":
+		return "DATE"
+	}
+	return ""
+}
+
+// fieldDocs collects the doc comments of struct fields, keyed by type name and field name
+// A field without a doc comment uses its trailing line comment
+func fieldDocs(files []*ast.File) map[string]map[string]string {
+	docs := make(map[string]map[string]string)
+	for _, file := range files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			spec, ok := n.(*ast.TypeSpec)
+			if !ok {
+				return true
+			}
+			st, ok := spec.Type.(*ast.StructType)
+			if !ok {
+				return false
+			}
+			fields := make(map[string]string)
+			for _, field := range st.Fields.List {
+				doc := strings.TrimSpace(field.Doc.Text())
+				if doc == "" {
+					doc = strings.TrimSpace(field.Comment.Text())
+				}
+				for _, name := range field.Names {
+					if doc != "" {
+						fields[name.Name] = doc
+					}
+				}
+			}
+			docs[spec.Name.Name] = fields
+			return false
+		})
+	}
+	return docs
+}
@@ -0,0 +1,134 @@
+package plate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Relations can be declared on models with plate struct tags instead of Relation values:
+//
+//	type Post struct {
+//		UserID string `spanner:"user_id" plate:"belongs_to=User,ref=ID,reverse=Posts,name=Author"`
+//	}
+//
+//	type PostTag struct {
+//		_      struct{} `plate:"junction"`
+//		PostID string   `spanner:"post_id" plate:"belongs_to=Post,reverse=Tags"`
+//		TagID  string   `spanner:"tag_id" plate:"belongs_to=Tag,reverse=Posts"`
+//	}
+//
+// ref defaults to ID, and name defaults to the field name without its ID suffix
+
+// junctionTag is the plate tag of the blank field that marks a junction model
+const junctionTag = "junction"
+
+// withTagRelations returns the schema with the relations declared by plate tags appended to
+// each table's Relations. Tables whose model carries the junction marker become junction tables
+// Invalid tags are reported together as a *ValidationError
+func (g *Generator) withTagRelations(schema Schema) (Schema, error) {
+	var result Schema
+	var diagnostics []Diagnostic
+	for _, tc := range schema.Tables {
+		relations, diags := g.tagRelations(tc.Schema, tc.Relations)
+		diagnostics = append(diagnostics, diags...)
+		if isJunctionModel(tc.Schema) {
+			result.Junctions = append(result.Junctions, JunctionConfig{Schema: tc.Schema, Relations: relations})
+			continue
+		}
+		result.Tables = append(result.Tables, TableConfig{Schema: tc.Schema, Relations: relations})
+	}
+	for _, jc := range schema.Junctions {
+		relations, diags := g.tagRelations(jc.Schema, jc.Relations)
+		diagnostics = append(diagnostics, diags...)
+		result.Junctions = append(result.Junctions, JunctionConfig{Schema: jc.Schema, Relations: relations})
+	}
+	if len(diagnostics) > 0 {
+		return Schema{}, &ValidationError{Diagnostics: diagnostics}
+	}
+	return result, nil
+}
+
+// tagRelations appends the relations declared by the plate tags of a table's fields to its relations
+func (g *Generator) tagRelations(schema TableSchema, relations []Relation) ([]Relation, []Diagnostic) {
+	typeName := g.getTypeName(schema)
+	result := append([]Relation(nil), relations...)
+	var diagnostics []Diagnostic
+	for _, col := range g.getColumns(schema) {
+		if col.Relation == "" {
+			continue
+		}
+		subject := typeName + "." + col.Name
+		rel, err := parseRelationTag(col.Name, col.Relation)
+		if err != nil {
+			diagnostics = append(diagnostics, Diagnostic{Pos: col.Pos, Subject: subject, Message: err.Error()})
+			continue
+		}
+		rel.Pos = col.Pos
+		declared := false
+		for _, existing := range result {
+			declared = declared || existing.Name == rel.Name
+		}
+		if declared {
+			diagnostics = append(diagnostics, Diagnostic{
+				Pos:     col.Pos,
+				Subject: subject,
+				Message: fmt.Sprintf("relation %s is already declared", rel.Name),
+			})
+			continue
+		}
+		result = append(result, rel)
+	}
+	return result, diagnostics
+}
+
+// parseRelationTag parses a plate tag such as "belongs_to=User,ref=ID,reverse=Posts" on a field
+func parseRelationTag(field, tag string) (Relation, error) {
+	rel := Relation{From: field, To: "ID"}
+	for _, option := range strings.Split(tag, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(option), "=")
+		if key == junctionTag {
+			return Relation{}, fmt.Errorf("the junction marker belongs on a blank field: _ struct{} `plate:\"junction\"`")
+		}
+		if value == "" {
+			return Relation{}, fmt.Errorf("plate tag option %q needs a value", key)
+		}
+		switch key {
+		case "belongs_to":
+			rel.Target = value
+		case "ref":
+			rel.To = value
+		case "reverse":
+			rel.ReverseName = value
+		case "name":
+			rel.Name = value
+		default:
+			return Relation{}, fmt.Errorf("unknown plate tag option %q", key)
+		}
+	}
+	if rel.Target == "" {
+		return Relation{}, fmt.Errorf("plate tag %q has no belongs_to", tag)
+	}
+	if rel.Name == "" {
+		rel.Name = relationName(field, rel.Target)
+	}
+	return rel, nil
+}
+
+// isJunctionModel reports whether the model of a table carries the junction marker
+func isJunctionModel(schema TableSchema) bool {
+	if schema.Model == nil {
+		return schema.Junction
+	}
+	t := reflect.TypeOf(schema.Model)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Name == "_" && field.Tag.Get("plate") == junctionTag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,182 @@
+package plate_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rail44/plate"
+)
+
+func TestTagRelations(t *testing.T) {
+	models, err := plate.LoadModels(".", "./testdata/models")
+	if err != nil {
+		t.Fatalf("Failed to load models: %v", err)
+	}
+	table := func(model, tableName string) plate.TableSchema {
+		ts := models[model]
+		ts.TableName = tableName
+		return ts
+	}
+	untagged := func(ts plate.TableSchema) plate.TableSchema {
+		ts.Junction = false
+		ts.Columns = append([]plate.ColumnSchema(nil), ts.Columns...)
+		for i := range ts.Columns {
+			ts.Columns[i].Relation = ""
+		}
+		return ts
+	}
+
+	account := table("Account", "account")
+	article := table("Article", "article")
+	label := table("Label", "label")
+	articleLabel := table("ArticleLabel", "article_label")
+
+	tagged := plate.Schema{
+		Tables: []plate.TableConfig{
+			{Schema: account},
+			{Schema: article},
+			{Schema: label},
+			{Schema: articleLabel},
+		},
+	}
+	explicit := plate.Schema{
+		Tables: []plate.TableConfig{
+			{
+				Schema: untagged(account),
+				Relations: []plate.Relation{
+					{Name: "Manager", Target: "Account", From: "ManagerID", To: "ID", ReverseName: "Reports"},
+				},
+			},
+			{
+				Schema: untagged(article),
+				Relations: []plate.Relation{
+					{Name: "Author", Target: "Account", From: "AuthorID", To: "ID", ReverseName: "Articles"},
+				},
+			},
+			{Schema: untagged(label)},
+		},
+		Junctions: []plate.JunctionConfig{
+			{
+				Schema: untagged(articleLabel),
+				Relations: []plate.Relation{
+					{Name: "Article", Target: "Article", From: "ArticleID", To: "ID", ReverseName: "Labels"},
+					{Name: "Label", Target: "Label", From: "LabelID", To: "ID", ReverseName: "Articles"},
+				},
+			},
+		},
+	}
+
+	got, err := plate.NewGenerator().GenerateFiles(tagged, "./generated")
+	if err != nil {
+		t.Fatalf("Failed to generate from tags: %v", err)
+	}
+	want, err := plate.NewGenerator().GenerateFiles(explicit, "./generated")
+	if err != nil {
+		t.Fatalf("Failed to generate from relations: %v", err)
+	}
+	if !reflect.DeepEqual(got.Files, want.Files) {
+		for path := range want.Files {
+			if got.Files[path] != want.Files[path] {
+				t.Errorf("%s mismatch\ngot:\n%s\nwant:\n%s", path, got.Files[path], want.Files[path])
+			}
+		}
+	}
+}
+
+type tagUser struct {
+	ID        string `spanner:"id" spannerType:"STRING(36)"`
+	ManagerID string `spanner:"manager_id" spannerType:"STRING(36)" plate:"belongs_to=tagUser,reverse=Reports"`
+}
+
+type tagPost struct {
+	ID     string `spanner:"id" spannerType:"STRING(36)"`
+	UserID string `spanner:"user_id" spannerType:"STRING(36)" plate:"belongs_to=tagUser,ref=ID,reverse=Posts,name=Author"`
+}
+
+type tagPostTag struct {
+	_      struct{} `plate:"junction"`
+	PostID string   `spanner:"post_id" spannerType:"STRING(36)" plate:"belongs_to=tagPost,reverse=Tags"`
+	TagID  string   `spanner:"tag_id" spannerType:"STRING(36)" plate:"belongs_to=tagUser,reverse=TaggedPosts"`
+}
+
+func TestTagRelationsFromModels(t *testing.T) {
+	schema := plate.Schema{
+		Tables: []plate.TableConfig{
+			{Schema: plate.TableSchema{TableName: "user", Model: tagUser{}}},
+			{Schema: plate.TableSchema{TableName: "post", Model: tagPost{}}},
+			{Schema: plate.TableSchema{TableName: "post_tag", Model: tagPostTag{}}},
+		},
+	}
+
+	got, err := plate.NewGenerator().GenerateDDL(schema)
+	if err != nil {
+		t.Fatalf("Failed to generate DDL: %v", err)
+	}
+	for _, want := range []string{
+		"CONSTRAINT FK_user_manager FOREIGN KEY (manager_id) REFERENCES user (id)",
+		"CONSTRAINT FK_post_author FOREIGN KEY (user_id) REFERENCES user (id)",
+		"CONSTRAINT FK_post_tag_post FOREIGN KEY (post_id) REFERENCES post (id)",
+		"CONSTRAINT FK_post_tag_tag FOREIGN KEY (tag_id) REFERENCES user (id)\n) PRIMARY KEY (post_id, tag_id);",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("DDL does not contain %q\n%s", want, got)
+		}
+	}
+}
+
+type badTagPost struct {
+	UserID string `spanner:"user_id" plate:"belongs=tagUser"`
+}
+
+type noTargetPost struct {
+	UserID string `spanner:"user_id" plate:"reverse=Posts"`
+}
+
+type junctionFieldPost struct {
+	UserID string `spanner:"user_id" plate:"junction"`
+}
+
+func TestTagRelationErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		table   plate.TableConfig
+		wantErr string
+	}{
+		{
+			name:    "unknown option",
+			table:   plate.TableConfig{Schema: plate.TableSchema{TableName: "post", Model: badTagPost{}}},
+			wantErr: `badTagPost.UserID: unknown plate tag option "belongs"`,
+		},
+		{
+			name:    "missing belongs_to",
+			table:   plate.TableConfig{Schema: plate.TableSchema{TableName: "post", Model: noTargetPost{}}},
+			wantErr: `noTargetPost.UserID: plate tag "reverse=Posts" has no belongs_to`,
+		},
+		{
+			name:    "junction marker on a column",
+			table:   plate.TableConfig{Schema: plate.TableSchema{TableName: "post", Model: junctionFieldPost{}}},
+			wantErr: "junction marker belongs on a blank field",
+		},
+		{
+			name: "relation declared twice",
+			table: plate.TableConfig{
+				Schema: plate.TableSchema{TableName: "post", Model: tagPost{}},
+				Relations: []plate.Relation{
+					{Name: "Author", Target: "tagUser", From: "UserID", To: "ID"},
+				},
+			},
+			wantErr: "tagPost.UserID: relation Author is already declared",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := plate.Schema{Tables: []plate.TableConfig{tt.table}}
+			_, err := plate.NewGenerator().GenerateFiles(schema, "./generated")
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Error mismatch\ngot:  %v\nwant: %s", err, tt.wantErr)
+			}
+		})
+	}
+}
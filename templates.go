@@ -13,6 +13,7 @@ type templateData struct {
 	TableName   string
 	Columns     []columnInfo
 	Relations   []generatedRelation
+	Models      []modelTemplateData
 	Imports     []string
 }
 
@@ -33,6 +34,20 @@ func ({{.TypeName}}) TableName() string { return "{{.TableName}}" }
 {{end}}
 `
 
+const modelsTemplate = `// Code generated by plate; DO NOT EDIT.
+
+package models
+{{if .Imports}}
+import (
+{{range .Imports}}	"{{.}}"
+{{end}})
+{{end}}{{range .Models}}
+// {{.TypeName}} represents a row of the {{.TableName}} table
+type {{.TypeName}} struct {
+{{range .Fields}}	{{.Name}} {{.GoType}} ` + "`" + `spanner:"{{.ColumnName}}" spannerType:"{{.SpannerType}}"` + "`" + `
+{{end}}}
+{{end}}`
+
 const queryBuilderTemplate = `// Code generated by plate; DO NOT EDIT.
 
 package {{.PackageName}}
@@ -42,8 +57,8 @@ import (
 {{end}})
 
 // Column accessors for type-safe column references
-{{range .Columns}}func {{.Name}}() types.Column[tables.{{$.TypeName}}, {{.GoType}}] {
-	return types.Column[tables.{{$.TypeName}}, {{.GoType}}]{Name: "{{.ColumnName}}"}
+{{range .Columns}}{{if .Doc}}{{comment .Doc}}{{end}}func {{.Name}}() {{.AccessorType $.TypeName}} {
+	return {{.AccessorValue $.TypeName}}
 }
 
 {{end}}
@@ -52,14 +67,87 @@ func Select(opts ...types.Option[tables.{{.TypeName}}]) (string, []any) {
 	return query.Select(opts...)
 }
 
+// Statement creates a SELECT query for the {{.TypeName}} table with named parameters
+func Statement(opts ...types.Option[tables.{{.TypeName}}]) query.Statement {
+	return query.Build(opts...)
+}
+
+// Insert creates an INSERT statement for the {{.TypeName}} table
+func Insert(opts ...types.InsertOption[tables.{{.TypeName}}]) (string, []any, error) {
+	return query.Insert(opts...)
+}
+
+// Values adds a row of column values to an INSERT statement
+func Values(values ...types.Assignment[tables.{{.TypeName}}]) types.InsertClause[tables.{{.TypeName}}] {
+	return query.Values(values...)
+}
+
+// OrUpdate turns an INSERT statement into INSERT OR UPDATE
+func OrUpdate() types.InsertClause[tables.{{.TypeName}}] {
+	return query.OrUpdate[tables.{{.TypeName}}]()
+}
+
+// OrIgnore turns an INSERT statement into INSERT OR IGNORE
+func OrIgnore() types.InsertClause[tables.{{.TypeName}}] {
+	return query.OrIgnore[tables.{{.TypeName}}]()
+}
+
+// Update creates an UPDATE statement for the {{.TypeName}} table
+// At least one condition is required; pass AllRows() to update every row
+func Update(sets []types.Assignment[tables.{{.TypeName}}], opts ...types.UpdateOption[tables.{{.TypeName}}]) (string, []any, error) {
+	return query.Update(sets, opts...)
+}
+
+// Delete creates a DELETE statement for the {{.TypeName}} table
+// At least one condition is required; pass AllRows() to delete every row
+func Delete(opts ...types.DeleteOption[tables.{{.TypeName}}]) (string, []any, error) {
+	return query.Delete(opts...)
+}
+
+// Returning adds a THEN RETURN clause to an INSERT, UPDATE or DELETE statement
+// All columns are returned when none are given
+func Returning(columns ...types.ColumnRef[tables.{{.TypeName}}]) types.ReturningOption[tables.{{.TypeName}}] {
+	return query.Returning(columns...)
+}
+
 // Limit adds a LIMIT clause to the query
-func Limit(count int) types.QueryOption[tables.{{.TypeName}}] {
+func Limit(count int) types.QueryClause[tables.{{.TypeName}}] {
 	return query.Limit[tables.{{.TypeName}}](count)
 }
 
+// Columns selects only the given columns or expressions instead of all columns
+func Columns(columns ...types.Selectable[tables.{{.TypeName}}]) types.QueryOption[tables.{{.TypeName}}] {
+	return query.Columns(columns...)
+}
+
+// AddColumns appends columns or expressions to the SELECT list, keeping all columns selected
+func AddColumns(columns ...types.Selectable[tables.{{.TypeName}}]) types.QueryOption[tables.{{.TypeName}}] {
+	return query.AddColumns(columns...)
+}
+
 // OrderBy adds an ORDER BY clause to the query
-func OrderBy[V any](column types.Column[tables.{{.TypeName}}, V], dir ast.Direction) types.QueryOption[tables.{{.TypeName}}] {
-	return query.OrderBy(column, dir)
+func OrderBy(expr types.Expression[tables.{{.TypeName}}], dir ast.Direction) types.QueryOption[tables.{{.TypeName}}] {
+	return query.OrderBy(expr, dir)
+}
+
+// GroupBy adds a GROUP BY clause to the query
+func GroupBy(exprs ...types.Expression[tables.{{.TypeName}}]) types.QueryClause[tables.{{.TypeName}}] {
+	return query.GroupBy(exprs...)
+}
+
+// Having adds a HAVING clause to the query
+func Having(opts ...types.ExprOption[tables.{{.TypeName}}]) types.QueryClause[tables.{{.TypeName}}] {
+	return query.Having(opts...)
+}
+
+// CountAll creates a COUNT(*) aggregate
+func CountAll() types.Expr[tables.{{.TypeName}}, int64] {
+	return types.CountAll[tables.{{.TypeName}}]()
+}
+
+// Param creates an operand for a parameter value, such as a factor in arithmetic
+func Param[V any](value V) types.Expr[tables.{{.TypeName}}, V] {
+	return types.Param[tables.{{.TypeName}}](value)
 }
 
 // And creates an AND condition that groups multiple conditions
@@ -77,14 +165,25 @@ func Not(opt types.ExprOption[tables.{{.TypeName}}]) types.ExprOption[tables.{{.
 	return query.Not(opt)
 }
 
+// AllRows creates an always-true condition to explicitly target every row
+func AllRows() types.ExprOption[tables.{{.TypeName}}] {
+	return query.AllRows[tables.{{.TypeName}}]()
+}
+
+// Outer refers to a column of an enclosing query from conditions on {{.TypeName}}, such as a column
+// of the parent table inside its With, Where or Join relation to {{.TypeName}}
+func Outer[U types.Table, V any](operand types.Operand[U, V]) types.Expr[tables.{{.TypeName}}, V] {
+	return types.Outer[tables.{{.TypeName}}](operand)
+}
+
 
 {{range .Relations}}
 {{if eq .Type "belongs_to"}}// With{{.Name}} fetches related {{.Target}} as a nested struct
 func With{{.Name}}(opts ...types.Option[tables.{{.Target}}]) types.QueryOption[tables.{{$.TypeName}}] {
 	return query.WithOne[tables.{{$.TypeName}}, tables.{{.Target}}](
 		"{{.Name | toSnakeCase}}",
-		"{{.Target | toSnakeCase}}",
-		query.KeyPair{From: "{{.Keys.From | toSnakeCase}}", To: "{{.Keys.To | toSnakeCase}}"},
+		"{{.TargetTable}}",
+		query.KeyPair{From: "{{.Keys.From}}", To: "{{.Keys.To}}"},
 		opts...,
 	)
 }
@@ -92,8 +191,8 @@ func With{{.Name}}(opts ...types.Option[tables.{{.Target}}]) types.QueryOption[t
 // Where{{.Name}} filters {{$.TypeName}} by conditions on its {{.Name}}
 func Where{{.Name}}(opts ...types.Option[tables.{{.Target}}]) types.ExprOption[tables.{{$.TypeName}}] {
 	return query.WhereExists[tables.{{$.TypeName}}, tables.{{.Target}}](
-		"{{.Target | toSnakeCase}}",
-		query.KeyPair{From: "{{.Keys.From | toSnakeCase}}", To: "{{.Keys.To | toSnakeCase}}"},
+		"{{.TargetTable}}",
+		query.KeyPair{From: "{{.Keys.From}}", To: "{{.Keys.To}}"},
 		"",    // no junction table
 		query.KeyPair{},
 		opts...,
@@ -103,8 +202,8 @@ func Where{{.Name}}(opts ...types.Option[tables.{{.Target}}]) types.ExprOption[t
 func With{{.Name}}(opts ...types.Option[tables.{{.Target}}]) types.QueryOption[tables.{{$.TypeName}}] {
 	return query.WithMany[tables.{{$.TypeName}}, tables.{{.Target}}](
 		"{{.Name | toSnakeCase}}",
-		"{{.Target | toSnakeCase}}",
-		query.KeyPair{From: "{{.Keys.From | toSnakeCase}}", To: "{{.Keys.To | toSnakeCase}}"},
+		"{{.TargetTable}}",
+		query.KeyPair{From: "{{.Keys.From}}", To: "{{.Keys.To}}"},
 		opts...,
 	)
 }
@@ -112,21 +211,21 @@ func With{{.Name}}(opts ...types.Option[tables.{{.Target}}]) types.QueryOption[t
 // Where{{.Name}} filters {{$.TypeName}} by conditions on its {{.Name}}
 func Where{{.Name}}(opts ...types.Option[tables.{{.Target}}]) types.ExprOption[tables.{{$.TypeName}}] {
 	return query.WhereExists[tables.{{$.TypeName}}, tables.{{.Target}}](
-		"{{.Target | toSnakeCase}}",
-		query.KeyPair{From: "{{.Keys.From | toSnakeCase}}", To: "{{.Keys.To | toSnakeCase}}"},
+		"{{.TargetTable}}",
+		query.KeyPair{From: "{{.Keys.From}}", To: "{{.Keys.To}}"},
 		"",    // no junction table
 		query.KeyPair{},
 		opts...,
 	)
 }
-{{else if eq .Type "many_to_many"}}// With{{.Name}} fetches related {{.Target}} through {{.JunctionTable | toSnakeCase}} as a nested array of structs
+{{else if eq .Type "many_to_many"}}// With{{.Name}} fetches related {{.Target}} through {{.JunctionTable}} as a nested array of structs
 func With{{.Name}}(opts ...types.Option[tables.{{.Target}}]) types.QueryOption[tables.{{$.TypeName}}] {
 	return query.WithManyThrough[tables.{{$.TypeName}}, tables.{{.Target}}](
 		"{{.Name | toSnakeCase}}",
-		"{{.Target | toSnakeCase}}",
-		query.KeyPair{From: "{{.Keys.From | toSnakeCase}}", To: "{{.Keys.To | toSnakeCase}}"},
-		"{{.JunctionTable | toSnakeCase}}",
-		query.KeyPair{From: "{{.JunctionKeys.From | toSnakeCase}}", To: "{{.JunctionKeys.To | toSnakeCase}}"},
+		"{{.TargetTable}}",
+		query.KeyPair{From: "{{.Keys.From}}", To: "{{.Keys.To}}"},
+		"{{.JunctionTable}}",
+		query.KeyPair{From: "{{.JunctionKeys.From}}", To: "{{.JunctionKeys.To}}"},
 		opts...,
 	)
 }
@@ -134,20 +233,46 @@ func With{{.Name}}(opts ...types.Option[tables.{{.Target}}]) types.QueryOption[t
 // Where{{.Name}} filters {{$.TypeName}} by conditions on its {{.Name}}
 func Where{{.Name}}(opts ...types.Option[tables.{{.Target}}]) types.ExprOption[tables.{{$.TypeName}}] {
 	return query.WhereExists[tables.{{$.TypeName}}, tables.{{.Target}}](
-		"{{.Target | toSnakeCase}}",
-		query.KeyPair{From: "{{.Keys.From | toSnakeCase}}", To: "{{.Keys.To | toSnakeCase}}"},
-		"{{.JunctionTable | toSnakeCase}}",
-		query.KeyPair{From: "{{.JunctionKeys.From | toSnakeCase}}", To: "{{.JunctionKeys.To | toSnakeCase}}"},
+		"{{.TargetTable}}",
+		query.KeyPair{From: "{{.Keys.From}}", To: "{{.Keys.To}}"},
+		"{{.JunctionTable}}",
+		query.KeyPair{From: "{{.JunctionKeys.From}}", To: "{{.JunctionKeys.To}}"},
+		opts...,
+	)
+}
+{{end}}
+// Join{{.Name}} adds an INNER JOIN to the related {{.Target}}, aliased as {{.Name | toSnakeCase}}
+func Join{{.Name}}(opts ...types.JoinOption[tables.{{.Target}}]) types.QueryOption[tables.{{$.TypeName}}] {
+	return query.Join[tables.{{$.TypeName}}, tables.{{.Target}}](
+		ast.InnerJoin,
+		{{template "joinArgs" .}}
 		opts...,
 	)
 }
-{{end}}{{end}}
+
+// LeftJoin{{.Name}} adds a LEFT OUTER JOIN to the related {{.Target}}, aliased as {{.Name | toSnakeCase}}
+func LeftJoin{{.Name}}(opts ...types.JoinOption[tables.{{.Target}}]) types.QueryOption[tables.{{$.TypeName}}] {
+	return query.Join[tables.{{$.TypeName}}, tables.{{.Target}}](
+		ast.LeftOuterJoin,
+		{{template "joinArgs" .}}
+		opts...,
+	)
+}
+{{end}}
 `
 
+const joinArgsTemplate = `"{{.Name | toSnakeCase}}",
+		"{{.TargetTable}}",
+		query.KeyPair{From: "{{.Keys.From}}", To: "{{.Keys.To}}"},
+{{if .JunctionTable}}		"{{.JunctionTable}}",
+		query.KeyPair{From: "{{.JunctionKeys.From}}", To: "{{.JunctionKeys.To}}"},{{else}}		"", // no junction table
+		query.KeyPair{},{{end}}`
+
 // getTemplates returns initialized templates
 func getTemplates() (*template.Template, error) {
 	funcMap := template.FuncMap{
 		"toSnakeCase": toSnakeCase,
+		"comment":     comment,
 	}
 
 	tmpl := template.New("generator").Funcs(funcMap)
@@ -157,11 +282,21 @@ func getTemplates() (*template.Template, error) {
 		return nil, err
 	}
 
+	// Parse models template
+	if _, err := tmpl.New("models").Parse(modelsTemplate); err != nil {
+		return nil, err
+	}
+
 	// Parse query builder template
 	if _, err := tmpl.New("queryBuilder").Parse(queryBuilderTemplate); err != nil {
 		return nil, err
 	}
 
+	// Parse shared JOIN arguments template
+	if _, err := tmpl.New("joinArgs").Parse(joinArgsTemplate); err != nil {
+		return nil, err
+	}
+
 	return tmpl, nil
 }
 
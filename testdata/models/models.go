@@ -0,0 +1,52 @@
+// Package models holds models loaded from source by the tests
+package models
+
+import "time"
+
+// Status is a named string type
+type Status string
+
+// NullStatus stands in for a Null* type of the Spanner client
+type NullStatus struct{}
+
+// Account is stored in the account table
+type Account struct {
+	// ID is the primary key
+	ID string `spanner:"id" spannerType:"STRING(36)"`
+
+	// Email is the address used to sign in
+	// It is unique across accounts
+	Email     string     `spanner:"email"`
+	Status    Status     `spanner:"status"` // Lifecycle state of the account
+	Previous  NullStatus `spanner:"previous_status" spannerType:"STRING(16)"`
+	ManagerID *string    `spanner:"manager_id" spannerType:"STRING(36)" plate:"belongs_to=Account,reverse=Reports"`
+	Avatar    []byte     `spanner:"avatar"`
+	Scores    []int64    `spanner:"scores"`
+	CreatedAt time.Time  `spanner:"created_at"`
+
+	internal string
+	Ignored  string
+}
+
+// Article is written by an account
+type Article struct {
+	ID       string `spanner:"id"`
+	AuthorID string `spanner:"author_id" plate:"belongs_to=Account,name=Author,reverse=Articles"`
+}
+
+// Label is attached to articles
+type Label struct {
+	ID string `spanner:"id"`
+}
+
+// ArticleLabel links articles and labels
+type ArticleLabel struct {
+	_         struct{} `plate:"junction"`
+	ArticleID string   `spanner:"article_id" plate:"belongs_to=Article,reverse=Labels"`
+	LabelID   string   `spanner:"label_id" plate:"belongs_to=Label,reverse=Articles"`
+}
+
+// Note has no spanner tags and is not a model
+type Note struct {
+	Text string
+}
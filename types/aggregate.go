@@ -0,0 +1,76 @@
+package types
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// call creates a function call with the column as its first argument
+func (c BaseColumn[T, V]) call(name string, extra ...ast.Arg) func(s *State) ast.Expr {
+	return func(s *State) ast.Expr {
+		return &ast.CallExpr{
+			Func: &ast.Path{
+				Idents: []*ast.Ident{{Name: name}},
+			},
+			Args: append([]ast.Arg{&ast.ExprArg{Expr: c.BuildExpr(s)}}, extra...),
+		}
+	}
+}
+
+// call creates a function call with the column as its first argument
+func (c Column[T, V]) call(name string, extra ...ast.Arg) func(s *State) ast.Expr {
+	return c.base().call(name, extra...)
+}
+
+// Count creates a COUNT(column) aggregate, which skips NULL values
+func (c BaseColumn[T, V]) Count() Expr[T, int64] {
+	return NewExpr[T, int64](c.call("COUNT"))
+}
+
+// Count creates a COUNT(column) aggregate, which skips NULL values
+func (c Column[T, V]) Count() Expr[T, int64] {
+	return c.base().Count()
+}
+
+// Min creates a MIN(column) aggregate
+func (c Column[T, V]) Min() Expr[T, V] {
+	return NewExpr[T, V](c.call("MIN"))
+}
+
+// Max creates a MAX(column) aggregate
+func (c Column[T, V]) Max() Expr[T, V] {
+	return NewExpr[T, V](c.call("MAX"))
+}
+
+// ArrayAgg creates an ARRAY_AGG(column) aggregate
+func (c Column[T, V]) ArrayAgg() Expr[T, []V] {
+	return NewExpr[T, []V](c.call("ARRAY_AGG"))
+}
+
+// Sum creates a SUM(column) aggregate
+func (c NumericColumn[T, V]) Sum() Expr[T, V] {
+	return NewExpr[T, V](c.call("SUM"))
+}
+
+// Avg creates an AVG(column) aggregate
+func (c NumericColumn[T, V]) Avg() Expr[T, float64] {
+	return NewExpr[T, float64](c.call("AVG"))
+}
+
+// Avg creates an AVG(column) aggregate, which is NUMERIC for NUMERIC columns
+func (c DecimalColumn[T, V]) Avg() Expr[T, V] {
+	return NewExpr[T, V](c.call("AVG"))
+}
+
+// StringAgg creates a STRING_AGG(column, delimiter) aggregate
+func (c StringColumn[T, V]) StringAgg(delimiter string) Expr[T, string] {
+	return NewExpr[T, string](c.call("STRING_AGG", &ast.ExprArg{
+		Expr: &ast.StringLiteral{Value: delimiter},
+	}))
+}
+
+// CountAll creates a COUNT(*) aggregate
+func CountAll[T Table]() Expr[T, int64] {
+	return NewExpr[T, int64](func(s *State) ast.Expr {
+		return &ast.CountStarExpr{}
+	})
+}
@@ -0,0 +1,124 @@
+package types
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// NumericExpr is a numeric expression, such as the result of arithmetic on numeric columns
+// Arithmetic can be chained, and parentheses are added where precedence or associativity requires them
+type NumericExpr[T Table, V any] struct {
+	Expr[T, V]
+}
+
+// StringExpr is a string expression, such as the result of concatenating string columns
+type StringExpr[T Table, V any] struct {
+	Expr[T, V]
+}
+
+// arithmetic creates an expression applying a binary operator to two operands
+// A binary right operand is grouped, since operators of the same precedence associate to the left
+func arithmetic(left func(s *State) ast.Expr, op ast.BinaryOp, right func(s *State) ast.Expr) func(s *State) ast.Expr {
+	return func(s *State) ast.Expr {
+		leftExpr := left(s)
+		rightExpr := right(s)
+		if _, ok := rightExpr.(*ast.BinaryExpr); ok {
+			rightExpr = &ast.ParenExpr{Expr: rightExpr}
+		}
+		return &ast.BinaryExpr{
+			Left:  leftExpr,
+			Op:    op,
+			Right: rightExpr,
+		}
+	}
+}
+
+// negate creates an expression applying unary minus to an operand
+// A unary operand is grouped, since -- starts a comment in Spanner SQL
+func negate(operand func(s *State) ast.Expr) func(s *State) ast.Expr {
+	return func(s *State) ast.Expr {
+		expr := operand(s)
+		if _, ok := expr.(*ast.UnaryExpr); ok {
+			expr = &ast.ParenExpr{Expr: expr}
+		}
+		return &ast.UnaryExpr{
+			Op:   ast.OpMinus,
+			Expr: expr,
+		}
+	}
+}
+
+// concat creates an expression concatenating operands with ||
+func concat[T Table, V any](first func(s *State) ast.Expr, others []Operand[T, V]) func(s *State) ast.Expr {
+	build := first
+	for _, other := range others {
+		build = arithmetic(build, ast.OpConcat, other.BuildExpr)
+	}
+	return build
+}
+
+// Add creates a column + operand expression
+func (c NumericColumn[T, V]) Add(other Operand[T, V]) NumericExpr[T, V] {
+	return NumericExpr[T, V]{NewExpr[T, V](arithmetic(c.BuildExpr, ast.OpAdd, other.BuildExpr))}
+}
+
+// Sub creates a column - operand expression
+func (c NumericColumn[T, V]) Sub(other Operand[T, V]) NumericExpr[T, V] {
+	return NumericExpr[T, V]{NewExpr[T, V](arithmetic(c.BuildExpr, ast.OpSub, other.BuildExpr))}
+}
+
+// Mul creates a column * operand expression
+func (c NumericColumn[T, V]) Mul(other Operand[T, V]) NumericExpr[T, V] {
+	return NumericExpr[T, V]{NewExpr[T, V](arithmetic(c.BuildExpr, ast.OpMul, other.BuildExpr))}
+}
+
+// Div creates a column / operand expression
+// Spanner evaluates / on INT64 and FLOAT64 values to FLOAT64
+func (c NumericColumn[T, V]) Div(other Operand[T, V]) NumericExpr[T, float64] {
+	return NumericExpr[T, float64]{NewExpr[T, float64](arithmetic(c.BuildExpr, ast.OpDiv, other.BuildExpr))}
+}
+
+// Div creates a column / operand expression, which is NUMERIC for NUMERIC columns
+func (c DecimalColumn[T, V]) Div(other Operand[T, V]) NumericExpr[T, V] {
+	return NumericExpr[T, V]{NewExpr[T, V](arithmetic(c.BuildExpr, ast.OpDiv, other.BuildExpr))}
+}
+
+// Neg creates a -column expression
+func (c NumericColumn[T, V]) Neg() NumericExpr[T, V] {
+	return NumericExpr[T, V]{NewExpr[T, V](negate(c.BuildExpr))}
+}
+
+// Add creates an expression + operand expression
+func (e NumericExpr[T, V]) Add(other Operand[T, V]) NumericExpr[T, V] {
+	return NumericExpr[T, V]{NewExpr[T, V](arithmetic(e.build, ast.OpAdd, other.BuildExpr))}
+}
+
+// Sub creates an expression - operand expression
+func (e NumericExpr[T, V]) Sub(other Operand[T, V]) NumericExpr[T, V] {
+	return NumericExpr[T, V]{NewExpr[T, V](arithmetic(e.build, ast.OpSub, other.BuildExpr))}
+}
+
+// Mul creates an expression * operand expression
+func (e NumericExpr[T, V]) Mul(other Operand[T, V]) NumericExpr[T, V] {
+	return NumericExpr[T, V]{NewExpr[T, V](arithmetic(e.build, ast.OpMul, other.BuildExpr))}
+}
+
+// Div creates an expression / operand expression
+// Spanner evaluates / on INT64 and FLOAT64 values to FLOAT64
+func (e NumericExpr[T, V]) Div(other Operand[T, V]) NumericExpr[T, float64] {
+	return NumericExpr[T, float64]{NewExpr[T, float64](arithmetic(e.build, ast.OpDiv, other.BuildExpr))}
+}
+
+// Neg creates a -expression expression
+func (e NumericExpr[T, V]) Neg() NumericExpr[T, V] {
+	return NumericExpr[T, V]{NewExpr[T, V](negate(e.build))}
+}
+
+// Concat creates a column || operand || ... expression
+func (c StringColumn[T, V]) Concat(others ...Operand[T, V]) StringExpr[T, V] {
+	return StringExpr[T, V]{NewExpr[T, V](concat(c.BuildExpr, others))}
+}
+
+// Concat creates an expression || operand || ... expression
+func (e StringExpr[T, V]) Concat(others ...Operand[T, V]) StringExpr[T, V] {
+	return StringExpr[T, V]{NewExpr[T, V](concat(e.build, others))}
+}
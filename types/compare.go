@@ -0,0 +1,135 @@
+package types
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// Operand is implemented by typed expressions of table T that evaluate to a value of type V,
+// such as columns and Expr. Comparisons between operands require the same value type
+type Operand[T Table, V any] interface {
+	Expression[T]
+	value() V
+}
+
+// value ties the column to its value type
+func (c Column[T, V]) value() V {
+	var v V
+	return v
+}
+
+// value ties the expression to its value type
+func (e Expr[T, V]) value() V {
+	var v V
+	return v
+}
+
+// binaryOperand creates a condition comparing two expressions
+func binaryOperand[T Table](left func(s *State) ast.Expr, op ast.BinaryOp, right func(s *State) ast.Expr) ExprOption[T] {
+	return func(s *State, expr *ast.Expr) {
+		*expr = &ast.BinaryExpr{
+			Left:  left(s),
+			Op:    op,
+			Right: right(s),
+		}
+	}
+}
+
+// OpColumn creates a condition comparing the column with another operand using the specified operator
+func (c Column[T, V]) OpColumn(op ast.BinaryOp, other Operand[T, V]) ExprOption[T] {
+	return binaryOperand[T](c.BuildExpr, op, other.BuildExpr)
+}
+
+// EqColumn creates an equality condition between two operands (=)
+func (c Column[T, V]) EqColumn(other Operand[T, V]) ExprOption[T] {
+	return c.OpColumn(ast.OpEqual, other)
+}
+
+// NeColumn creates a not equal condition between two operands (!=)
+func (c Column[T, V]) NeColumn(other Operand[T, V]) ExprOption[T] {
+	return c.OpColumn(ast.OpNotEqual, other)
+}
+
+// LtColumn creates a less than condition between two operands (<)
+func (c Column[T, V]) LtColumn(other Operand[T, V]) ExprOption[T] {
+	return c.OpColumn(ast.OpLess, other)
+}
+
+// GtColumn creates a greater than condition between two operands (>)
+func (c Column[T, V]) GtColumn(other Operand[T, V]) ExprOption[T] {
+	return c.OpColumn(ast.OpGreater, other)
+}
+
+// LeColumn creates a less than or equal condition between two operands (<=)
+func (c Column[T, V]) LeColumn(other Operand[T, V]) ExprOption[T] {
+	return c.OpColumn(ast.OpLessEqual, other)
+}
+
+// GeColumn creates a greater than or equal condition between two operands (>=)
+func (c Column[T, V]) GeColumn(other Operand[T, V]) ExprOption[T] {
+	return c.OpColumn(ast.OpGreaterEqual, other)
+}
+
+// OpColumn creates a condition comparing the expression with another operand using the specified operator
+func (e Expr[T, V]) OpColumn(op ast.BinaryOp, other Operand[T, V]) ExprOption[T] {
+	return binaryOperand[T](e.build, op, other.BuildExpr)
+}
+
+// EqColumn creates an equality condition between two operands (=)
+func (e Expr[T, V]) EqColumn(other Operand[T, V]) ExprOption[T] {
+	return e.OpColumn(ast.OpEqual, other)
+}
+
+// NeColumn creates a not equal condition between two operands (!=)
+func (e Expr[T, V]) NeColumn(other Operand[T, V]) ExprOption[T] {
+	return e.OpColumn(ast.OpNotEqual, other)
+}
+
+// LtColumn creates a less than condition between two operands (<)
+func (e Expr[T, V]) LtColumn(other Operand[T, V]) ExprOption[T] {
+	return e.OpColumn(ast.OpLess, other)
+}
+
+// GtColumn creates a greater than condition between two operands (>)
+func (e Expr[T, V]) GtColumn(other Operand[T, V]) ExprOption[T] {
+	return e.OpColumn(ast.OpGreater, other)
+}
+
+// LeColumn creates a less than or equal condition between two operands (<=)
+func (e Expr[T, V]) LeColumn(other Operand[T, V]) ExprOption[T] {
+	return e.OpColumn(ast.OpLessEqual, other)
+}
+
+// GeColumn creates a greater than or equal condition between two operands (>=)
+func (e Expr[T, V]) GeColumn(other Operand[T, V]) ExprOption[T] {
+	return e.OpColumn(ast.OpGreaterEqual, other)
+}
+
+// Outer refers to an operand of table U from a subquery or join of table T, so that it can be
+// compared with columns of T. The operand is built in the nearest enclosing scope of U, and is
+// qualified by that scope's alias. Outside of any enclosing scope of U, it is qualified by the table
+// name of U, so that Spanner rejects the query rather than comparing columns of the current table
+func Outer[T Table, U Table, V any](operand Operand[U, V]) Expr[T, V] {
+	return NewExpr[T, V](func(s *State) ast.Expr {
+		var u U
+		scope := s.enclosingScope(u.TableName())
+		if scope == nil {
+			scope = &State{CurrentTable: u.TableName()}
+		}
+		// Build in a copy of the enclosing scope that carries the current parameters
+		outer := *scope
+		outer.Params = s.Params
+		built := operand.BuildExpr(&outer)
+		s.Params = outer.Params
+		return built
+	})
+}
+
+// enclosingScope returns the nearest enclosing scope whose current table is the given table, or nil
+func (s *State) enclosingScope(table string) *State {
+	for scope := s.Parent; scope != nil; scope = scope.Parent {
+		if scope.CurrentTable == table {
+			return scope
+		}
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// Assignment represents a column/value pair used by DML statements
+type Assignment[T Table] struct {
+	Column string
+	Value  any
+}
+
+// Set creates an assignment of the value to the column
+func (c BaseColumn[T, V]) Set(value V) Assignment[T] {
+	return Assignment[T]{
+		Column: c.Name,
+		Value:  value,
+	}
+}
+
+// Set creates an assignment of the value to the column
+func (c Column[T, V]) Set(value V) Assignment[T] {
+	return c.base().Set(value)
+}
+
+// InsertOption represents an option that can be applied to an INSERT statement
+type InsertOption[T Table] interface {
+	ApplyInsert(s *State, stmt *ast.Insert)
+}
+
+// InsertClause represents an option that modifies an INSERT statement directly
+type InsertClause[T Table] func(*State, *ast.Insert)
+
+// ApplyInsert implements the InsertOption interface for InsertClause
+func (opt InsertClause[T]) ApplyInsert(s *State, stmt *ast.Insert) {
+	opt(s, stmt)
+}
+
+// UpdateOption represents an option that can be applied to an UPDATE statement
+type UpdateOption[T Table] interface {
+	ApplyUpdate(s *State, stmt *ast.Update)
+}
+
+// ApplyUpdate implements the UpdateOption interface for ExprOption
+func (opt ExprOption[T]) ApplyUpdate(s *State, stmt *ast.Update) {
+	stmt.Where = opt.addTo(s, stmt.Where)
+}
+
+// DeleteOption represents an option that can be applied to a DELETE statement
+type DeleteOption[T Table] interface {
+	ApplyDelete(s *State, stmt *ast.Delete)
+}
+
+// ApplyDelete implements the DeleteOption interface for ExprOption
+func (opt ExprOption[T]) ApplyDelete(s *State, stmt *ast.Delete) {
+	stmt.Where = opt.addTo(s, stmt.Where)
+}
+
+// ReturningOption represents a THEN RETURN clause that can be applied to any DML statement
+type ReturningOption[T Table] func(*State) *ast.ThenReturn
+
+// addTo appends the returned items to an existing THEN RETURN clause
+func (opt ReturningOption[T]) addTo(s *State, ret *ast.ThenReturn) *ast.ThenReturn {
+	next := opt(s)
+	if ret == nil {
+		return next
+	}
+	return &ast.ThenReturn{
+		Items: append(ret.Items, next.Items...),
+	}
+}
+
+// ApplyInsert implements the InsertOption interface for ReturningOption
+func (opt ReturningOption[T]) ApplyInsert(s *State, stmt *ast.Insert) {
+	stmt.ThenReturn = opt.addTo(s, stmt.ThenReturn)
+}
+
+// ApplyUpdate implements the UpdateOption interface for ReturningOption
+func (opt ReturningOption[T]) ApplyUpdate(s *State, stmt *ast.Update) {
+	stmt.ThenReturn = opt.addTo(s, stmt.ThenReturn)
+}
+
+// ApplyDelete implements the DeleteOption interface for ReturningOption
+func (opt ReturningOption[T]) ApplyDelete(s *State, stmt *ast.Delete) {
+	stmt.ThenReturn = opt.addTo(s, stmt.ThenReturn)
+}
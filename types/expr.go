@@ -0,0 +1,130 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// Expression is implemented by typed SQL expressions of table T, such as columns and aggregates
+type Expression[T Table] interface {
+	BuildExpr(s *State) ast.Expr
+	table() T
+}
+
+// Selectable is implemented by anything that can be listed in the SELECT clause of table T
+type Selectable[T Table] interface {
+	SelectItem(s *State) ast.SelectItem
+	table() T
+}
+
+// Expr represents a typed SQL expression on table T that evaluates to a value of type V
+type Expr[T Table, V any] struct {
+	build func(s *State) ast.Expr
+}
+
+// NewExpr creates a typed expression from a function that builds its AST
+func NewExpr[T Table, V any](build func(s *State) ast.Expr) Expr[T, V] {
+	return Expr[T, V]{build: build}
+}
+
+// Param creates an expression for a parameter value, to be used as an operand
+func Param[T Table, V any](value V) Expr[T, V] {
+	return NewExpr[T, V](func(s *State) ast.Expr {
+		return param(s, value)
+	})
+}
+
+// BuildExpr builds the expression AST
+func (e Expr[T, V]) BuildExpr(s *State) ast.Expr {
+	return e.build(s)
+}
+
+// table ties the expression to its table type
+func (e Expr[T, V]) table() T {
+	var t T
+	return t
+}
+
+// SelectItem builds the expression as a SELECT list item
+func (e Expr[T, V]) SelectItem(s *State) ast.SelectItem {
+	return &ast.ExprSelectItem{Expr: e.build(s)}
+}
+
+// As gives the expression a name in the SELECT clause
+func (e Expr[T, V]) As(alias string) Aliased[T] {
+	return Aliased[T]{expr: e.build, alias: alias}
+}
+
+// Op creates a condition using the specified operator and value
+func (e Expr[T, V]) Op(op ast.BinaryOp, value V) ExprOption[T] {
+	return binaryParam[T](e.build, op, value)
+}
+
+// Eq creates an equality condition (=)
+func (e Expr[T, V]) Eq(value V) ExprOption[T] {
+	return e.Op(ast.OpEqual, value)
+}
+
+// Ne creates a not equal condition (!=)
+func (e Expr[T, V]) Ne(value V) ExprOption[T] {
+	return e.Op(ast.OpNotEqual, value)
+}
+
+// Lt creates a less than condition (<)
+func (e Expr[T, V]) Lt(value V) ExprOption[T] {
+	return e.Op(ast.OpLess, value)
+}
+
+// Gt creates a greater than condition (>)
+func (e Expr[T, V]) Gt(value V) ExprOption[T] {
+	return e.Op(ast.OpGreater, value)
+}
+
+// Le creates a less than or equal condition (<=)
+func (e Expr[T, V]) Le(value V) ExprOption[T] {
+	return e.Op(ast.OpLessEqual, value)
+}
+
+// Ge creates a greater than or equal condition (>=)
+func (e Expr[T, V]) Ge(value V) ExprOption[T] {
+	return e.Op(ast.OpGreaterEqual, value)
+}
+
+// Aliased represents an expression with a name in the SELECT clause
+type Aliased[T Table] struct {
+	expr  func(s *State) ast.Expr
+	alias string
+}
+
+// SelectItem builds the aliased expression as a SELECT list item
+func (a Aliased[T]) SelectItem(s *State) ast.SelectItem {
+	return &ast.Alias{
+		Expr: a.expr(s),
+		As: &ast.AsAlias{
+			Alias: &ast.Ident{Name: a.alias},
+		},
+	}
+}
+
+// table ties the aliased expression to its table type
+func (a Aliased[T]) table() T {
+	var t T
+	return t
+}
+
+// binaryParam creates a condition comparing an expression with a parameter value
+func binaryParam[T Table](left func(s *State) ast.Expr, op ast.BinaryOp, value any) ExprOption[T] {
+	return func(s *State, expr *ast.Expr) {
+		l := left(s)
+		i := len(s.Params)
+		s.Params = append(s.Params, value)
+		*expr = &ast.BinaryExpr{
+			Left: l,
+			Op:   op,
+			Right: &ast.Param{
+				Name: fmt.Sprintf("p%d", i),
+			},
+		}
+	}
+}
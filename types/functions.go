@@ -0,0 +1,253 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// callExpr creates a call of the named SQL function
+func callExpr(name string, args ...ast.Expr) *ast.CallExpr {
+	call := &ast.CallExpr{
+		Func: &ast.Path{
+			Idents: []*ast.Ident{{Name: name}},
+		},
+	}
+	for _, arg := range args {
+		call.Args = append(call.Args, &ast.ExprArg{Expr: arg})
+	}
+	return call
+}
+
+// param adds a parameter value and returns its reference
+func param(s *State, value any) ast.Expr {
+	i := len(s.Params)
+	s.Params = append(s.Params, value)
+	return &ast.Param{Name: fmt.Sprintf("p%d", i)}
+}
+
+// callParam creates a call of the named SQL function with the column and a parameter value as arguments
+func (c Column[T, V]) callParam(name string, value any) func(s *State) ast.Expr {
+	return func(s *State) ast.Expr {
+		column := c.BuildExpr(s)
+		return callExpr(name, column, param(s, value))
+	}
+}
+
+// condition turns a boolean SQL expression into a condition
+func condition[T Table](build func(s *State) ast.Expr) ExprOption[T] {
+	return func(s *State, expr *ast.Expr) {
+		*expr = build(s)
+	}
+}
+
+// Lower creates a LOWER(column) expression
+func (c StringColumn[T, V]) Lower() StringExpr[T, V] {
+	return StringExpr[T, V]{NewExpr[T, V](c.call("LOWER"))}
+}
+
+// Upper creates an UPPER(column) expression
+func (c StringColumn[T, V]) Upper() StringExpr[T, V] {
+	return StringExpr[T, V]{NewExpr[T, V](c.call("UPPER"))}
+}
+
+// Length creates a LENGTH(column) expression, which counts characters
+func (c StringColumn[T, V]) Length() Expr[T, int64] {
+	return NewExpr[T, int64](c.call("LENGTH"))
+}
+
+// StartsWith creates a STARTS_WITH(column, prefix) condition
+func (c StringColumn[T, V]) StartsWith(prefix string) ExprOption[T] {
+	return condition[T](c.callParam("STARTS_WITH", prefix))
+}
+
+// EndsWith creates an ENDS_WITH(column, suffix) condition
+func (c StringColumn[T, V]) EndsWith(suffix string) ExprOption[T] {
+	return condition[T](c.callParam("ENDS_WITH", suffix))
+}
+
+// RegexpContains creates a REGEXP_CONTAINS(column, pattern) condition
+// The pattern uses RE2 syntax and matches anywhere in the value unless anchored
+func (c StringColumn[T, V]) RegexpContains(pattern string) ExprOption[T] {
+	return condition[T](c.callParam("REGEXP_CONTAINS", pattern))
+}
+
+// Length creates a LENGTH(column) expression, which counts bytes
+func (c BytesColumn[T, V]) Length() Expr[T, int64] {
+	return NewExpr[T, int64](c.call("LENGTH"))
+}
+
+// Abs creates an ABS(column) expression
+func (c NumericColumn[T, V]) Abs() NumericExpr[T, V] {
+	return NumericExpr[T, V]{NewExpr[T, V](c.call("ABS"))}
+}
+
+// DatePart is a part of a date, which TIMESTAMP and DATE columns can be truncated to and extracted
+type DatePart string
+
+// TimePart is a part of the time of day, which only TIMESTAMP columns can be truncated to and extracted
+type TimePart string
+
+// ExtractPart is a part that can be extracted from TIMESTAMP and DATE columns, but not truncated to
+type ExtractPart string
+
+const (
+	Microsecond TimePart    = "MICROSECOND"
+	Millisecond TimePart    = "MILLISECOND"
+	Second      TimePart    = "SECOND"
+	Minute      TimePart    = "MINUTE"
+	Hour        TimePart    = "HOUR"
+	Day         DatePart    = "DAY"
+	DayOfWeek   ExtractPart = "DAYOFWEEK"
+	DayOfYear   ExtractPart = "DAYOFYEAR"
+	Week        DatePart    = "WEEK"
+	ISOWeek     DatePart    = "ISOWEEK"
+	Month       DatePart    = "MONTH"
+	Quarter     DatePart    = "QUARTER"
+	Year        DatePart    = "YEAR"
+	ISOYear     DatePart    = "ISOYEAR"
+)
+
+// TimestampTruncPart is a DatePart or TimePart, accepted by TimeColumn.Trunc
+type TimestampTruncPart interface {
+	partName() string
+	timestampTruncPart()
+}
+
+// TimestampExtractPart is a DatePart, TimePart or ExtractPart, accepted by TimeColumn.Extract
+type TimestampExtractPart interface {
+	partName() string
+	timestampExtractPart()
+}
+
+// DateExtractPart is a DatePart or ExtractPart, accepted by DateColumn.Extract
+type DateExtractPart interface {
+	partName() string
+	dateExtractPart()
+}
+
+// partName returns the SQL name of the part
+func (p DatePart) partName() string {
+	return string(p)
+}
+
+// timestampTruncPart implements the TimestampTruncPart interface for DatePart
+func (DatePart) timestampTruncPart() {}
+
+// timestampExtractPart implements the TimestampExtractPart interface for DatePart
+func (DatePart) timestampExtractPart() {}
+
+// dateExtractPart implements the DateExtractPart interface for DatePart
+func (DatePart) dateExtractPart() {}
+
+// partName returns the SQL name of the part
+func (p TimePart) partName() string {
+	return string(p)
+}
+
+// timestampTruncPart implements the TimestampTruncPart interface for TimePart
+func (TimePart) timestampTruncPart() {}
+
+// timestampExtractPart implements the TimestampExtractPart interface for TimePart
+func (TimePart) timestampExtractPart() {}
+
+// partName returns the SQL name of the part
+func (p ExtractPart) partName() string {
+	return string(p)
+}
+
+// timestampExtractPart implements the TimestampExtractPart interface for ExtractPart
+func (ExtractPart) timestampExtractPart() {}
+
+// dateExtractPart implements the DateExtractPart interface for ExtractPart
+func (ExtractPart) dateExtractPart() {}
+
+// extract creates an EXTRACT(part FROM column) expression
+func (c Column[T, V]) extract(part string) Expr[T, int64] {
+	return NewExpr[T, int64](func(s *State) ast.Expr {
+		return &ast.ExtractExpr{
+			Part: &ast.Ident{Name: part},
+			Expr: c.BuildExpr(s),
+		}
+	})
+}
+
+// Trunc creates a TIMESTAMP_TRUNC(column, part) expression
+func (c TimeColumn[T, V]) Trunc(part TimestampTruncPart) Expr[T, V] {
+	return NewExpr[T, V](c.call("TIMESTAMP_TRUNC", &ast.ExprArg{Expr: &ast.Ident{Name: part.partName()}}))
+}
+
+// Extract creates an EXTRACT(part FROM column) expression, evaluated in UTC
+func (c TimeColumn[T, V]) Extract(part TimestampExtractPart) Expr[T, int64] {
+	return c.extract(part.partName())
+}
+
+// Trunc creates a DATE_TRUNC(column, part) expression
+func (c DateColumn[T, V]) Trunc(part DatePart) Expr[T, V] {
+	return NewExpr[T, V](c.call("DATE_TRUNC", &ast.ExprArg{Expr: &ast.Ident{Name: part.partName()}}))
+}
+
+// Extract creates an EXTRACT(part FROM column) expression
+func (c DateColumn[T, V]) Extract(part DateExtractPart) Expr[T, int64] {
+	return c.extract(part.partName())
+}
+
+// Coalesce creates a COALESCE(operands...) expression, which evaluates to the first non-NULL operand
+func Coalesce[T Table, V any](operands ...Operand[T, V]) Expr[T, V] {
+	return NewExpr[T, V](func(s *State) ast.Expr {
+		args := make([]ast.Expr, 0, len(operands))
+		for _, operand := range operands {
+			args = append(args, operand.BuildExpr(s))
+		}
+		return callExpr("COALESCE", args...)
+	})
+}
+
+// IfNull creates an IFNULL(operand, value) expression, which evaluates to value when the operand is NULL
+func IfNull[T Table, V any](operand Operand[T, V], value V) Expr[T, V] {
+	return NewExpr[T, V](func(s *State) ast.Expr {
+		expr := operand.BuildExpr(s)
+		return callExpr("IFNULL", expr, param(s, value))
+	})
+}
+
+// SQLType is a Spanner type that values of Go type V are cast to
+type SQLType[V any] struct {
+	name ast.ScalarTypeName
+}
+
+// NewSQLType creates a Spanner type for values of Go type V, such as DATE for civil.Date
+func NewSQLType[V any](name ast.ScalarTypeName) SQLType[V] {
+	return SQLType[V]{name: name}
+}
+
+var (
+	Int64Type     = NewSQLType[int64](ast.Int64TypeName)
+	Float64Type   = NewSQLType[float64](ast.Float64TypeName)
+	StringType    = NewSQLType[string](ast.StringTypeName)
+	BoolType      = NewSQLType[bool](ast.BoolTypeName)
+	BytesType     = NewSQLType[[]byte](ast.BytesTypeName)
+	TimestampType = NewSQLType[time.Time](ast.TimestampTypeName)
+)
+
+// cast creates a CAST or SAFE_CAST expression
+func cast[W any, T Table, V any](operand Operand[T, V], typ SQLType[W], safe bool) Expr[T, W] {
+	return NewExpr[T, W](func(s *State) ast.Expr {
+		return &ast.CastExpr{
+			Safe: safe,
+			Expr: operand.BuildExpr(s),
+			Type: &ast.SimpleType{Name: typ.name},
+		}
+	})
+}
+
+// Cast creates a CAST(operand AS type) expression, which fails the query when a value cannot be converted
+func Cast[W any, T Table, V any](operand Operand[T, V], typ SQLType[W]) Expr[T, W] {
+	return cast(operand, typ, false)
+}
+
+// SafeCast creates a SAFE_CAST(operand AS type) expression, which evaluates to NULL when a value cannot be converted
+func SafeCast[W any, T Table, V any](operand Operand[T, V], typ SQLType[W]) Expr[T, W] {
+	return cast(operand, typ, true)
+}
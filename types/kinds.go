@@ -0,0 +1,114 @@
+package types
+
+import (
+	"fmt"
+
+	"github.com/cloudspannerecosystem/memefish/ast"
+)
+
+// Column kinds embed Column, or BaseColumn for types Spanner cannot compare, and add the
+// operations Spanner accepts for their type
+// The generator picks the kind from the column's Spanner type, falling back to Column
+// for types it does not know
+
+// StringColumn is a STRING column
+type StringColumn[T Table, V any] struct {
+	Column[T, V]
+}
+
+// Like creates a LIKE condition
+func (c StringColumn[T, V]) Like(pattern string) ExprOption[T] {
+	return binaryParam[T](c.BuildExpr, ast.OpLike, pattern)
+}
+
+// NotLike creates a NOT LIKE condition
+func (c StringColumn[T, V]) NotLike(pattern string) ExprOption[T] {
+	return binaryParam[T](c.BuildExpr, ast.OpNotLike, pattern)
+}
+
+// NumericColumn is an INT64, FLOAT32 or FLOAT64 column
+type NumericColumn[T Table, V any] struct {
+	Column[T, V]
+}
+
+// DecimalColumn is a NUMERIC column
+// It has every numeric operation, but keeps NUMERIC precision where Spanner does
+type DecimalColumn[T Table, V any] struct {
+	NumericColumn[T, V]
+}
+
+// BoolColumn is a BOOL column
+type BoolColumn[T Table, V any] struct {
+	Column[T, V]
+}
+
+// isBoolExpr creates an IS TRUE or IS FALSE expression
+func (c BoolColumn[T, V]) isBoolExpr(value bool) ExprOption[T] {
+	return func(s *State, expr *ast.Expr) {
+		*expr = &ast.IsBoolExpr{
+			Left:  c.BuildExpr(s),
+			Right: value,
+		}
+	}
+}
+
+// IsTrue creates an IS TRUE condition, which is false for NULL
+func (c BoolColumn[T, V]) IsTrue() ExprOption[T] {
+	return c.isBoolExpr(true)
+}
+
+// IsFalse creates an IS FALSE condition, which is false for NULL
+func (c BoolColumn[T, V]) IsFalse() ExprOption[T] {
+	return c.isBoolExpr(false)
+}
+
+// TimeColumn is a TIMESTAMP column
+type TimeColumn[T Table, V any] struct {
+	Column[T, V]
+}
+
+// DateColumn is a DATE column
+type DateColumn[T Table, V any] struct {
+	Column[T, V]
+}
+
+// BytesColumn is a BYTES column
+type BytesColumn[T Table, V any] struct {
+	Column[T, V]
+}
+
+// ArrayColumn is an ARRAY column with elements of type E
+// Spanner cannot compare arrays, so it embeds BaseColumn rather than Column
+type ArrayColumn[T Table, E any] struct {
+	BaseColumn[T, []E]
+}
+
+// Contains creates a condition that the array holds the value (@p IN UNNEST(column))
+func (c ArrayColumn[T, E]) Contains(value E) ExprOption[T] {
+	return func(s *State, expr *ast.Expr) {
+		i := len(s.Params)
+		s.Params = append(s.Params, value)
+		*expr = &ast.InExpr{
+			Left:  &ast.Param{Name: fmt.Sprintf("p%d", i)},
+			Right: &ast.UnnestInCondition{Expr: c.BuildExpr(s)},
+		}
+	}
+}
+
+// Length creates an ARRAY_LENGTH(column) expression
+func (c ArrayColumn[T, E]) Length() Expr[T, int64] {
+	return NewExpr[T, int64](c.call("ARRAY_LENGTH"))
+}
+
+// JSONColumn is a JSON column
+// Spanner cannot compare JSON values, so it embeds BaseColumn rather than Column
+type JSONColumn[T Table, V any] struct {
+	BaseColumn[T, V]
+}
+
+// Value creates a JSON_VALUE(column, path) expression, which extracts a scalar as a string
+func (c JSONColumn[T, V]) Value(path string) Expr[T, string] {
+	return NewExpr[T, string](c.call("JSON_VALUE", &ast.ExprArg{
+		Expr: &ast.StringLiteral{Value: path},
+	}))
+}
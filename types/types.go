@@ -6,10 +6,14 @@ import (
 )
 
 type State struct {
-	Tables          map[string]struct{}
+	Tables          map[string]struct{} // Aliases introduced in this scope
 	Params          []any
 	CurrentTable    string           // Current table name for the query scope
+	Alias           string           // Alias of the current table, if it differs from the table name
 	SubqueryColumns []SubqueryColumn // Track subqueries to add to SELECT
+	Parent          *State           // Enclosing scope, nil for the top-level statement
+
+	aliasCounts map[string]int // Alias counters shared across the statement (root only)
 }
 
 // SubqueryColumn represents a column that will be added to SELECT as a subquery
@@ -18,22 +22,88 @@ type SubqueryColumn struct {
 	Subquery ast.Expr
 }
 
-// CurrentAlias returns the current table name
+// CurrentAlias returns the name used to qualify columns of the current table
 func (s *State) CurrentAlias() string {
+	if s.Alias != "" {
+		return s.Alias
+	}
 	return s.CurrentTable
 }
 
+// NamedParams returns the parameters keyed by their names in the SQL (p0, p1, ...)
+func (s *State) NamedParams() map[string]any {
+	params := make(map[string]any, len(s.Params))
+	for i, value := range s.Params {
+		params[fmt.Sprintf("p%d", i)] = value
+	}
+	return params
+}
+
+// UniqueAlias returns a name for the table that is not visible from this scope
+// The table name itself is used when free, otherwise a numbered alias (e.g. user_1)
+func (s *State) UniqueAlias(table string) string {
+	if !s.aliasInScope(table) {
+		return table
+	}
+
+	root := s
+	for root.Parent != nil {
+		root = root.Parent
+	}
+	if root.aliasCounts == nil {
+		root.aliasCounts = make(map[string]int)
+	}
+
+	for {
+		root.aliasCounts[table]++
+		alias := fmt.Sprintf("%s_%d", table, root.aliasCounts[table])
+		if !s.aliasInScope(alias) {
+			return alias
+		}
+	}
+}
+
+// aliasInScope reports whether the alias is introduced by this scope or an enclosing one
+func (s *State) aliasInScope(alias string) bool {
+	for scope := s; scope != nil; scope = scope.Parent {
+		if _, ok := scope.Tables[alias]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 // NewSubqueryState creates a new state for subqueries, inheriting params from parent
+// The target table gets a unique alias when its name is already used by an enclosing scope
 func (s *State) NewSubqueryState(targetTable string) *State {
+	alias := s.UniqueAlias(targetTable)
 	subState := &State{
 		Tables:       make(map[string]struct{}),
 		Params:       s.Params, // Share params with parent
 		CurrentTable: targetTable,
+		Parent:       s,
+	}
+	if alias != targetTable {
+		subState.Alias = alias
 	}
-	subState.Tables[targetTable] = struct{}{}
+	subState.Tables[alias] = struct{}{}
 	return subState
 }
 
+// NewJoinState creates a new state for a joined table, inheriting params from parent
+// The join alias is introduced in the parent scope, made unique if necessary
+func (s *State) NewJoinState(targetTable, alias string) *State {
+	alias = s.UniqueAlias(alias)
+	s.Tables[alias] = struct{}{}
+	return &State{
+		Tables:       make(map[string]struct{}),
+		Params:       s.Params, // Share params with parent
+		CurrentTable: targetTable,
+		Alias:        alias,
+		Parent:       s,
+	}
+}
+
 type Table interface {
 	TableName() string
 }
@@ -49,25 +119,41 @@ type ExprOption[T Table] func(*State, *ast.Expr)
 // Apply implements the Option interface for ExprOption
 func (opt ExprOption[T]) Apply(s *State, q *ast.Query) {
 	sl := q.Query.(*ast.Select)
-	if sl.Where == nil {
-		sl.Where = &ast.Where{}
-	}
+	sl.Where = opt.addTo(s, sl.Where)
+}
 
+// addTo builds the expression and adds it to the WHERE clause
+func (opt ExprOption[T]) addTo(s *State, where *ast.Where) *ast.Where {
 	var expr ast.Expr
 	opt(s, &expr)
 
-	if sl.Where.Expr == nil {
-		sl.Where.Expr = expr
-	} else {
-		// Combine with existing WHERE using AND
-		sl.Where.Expr = &ast.BinaryExpr{
+	if expr == nil {
+		return where
+	}
+	if where == nil || where.Expr == nil {
+		return &ast.Where{Expr: expr}
+	}
+
+	// Combine with existing WHERE using AND
+	return &ast.Where{
+		Expr: &ast.BinaryExpr{
 			Op:    ast.OpAnd,
-			Left:  sl.Where.Expr,
+			Left:  where.Expr,
 			Right: expr,
-		}
+		},
 	}
 }
 
+// JoinOption represents an option that can be applied to a joined table
+// It is implemented by ExprOption and QueryOption, but not by QueryClause
+type JoinOption[T Table] interface {
+	Option[T]
+	joinable()
+}
+
+// joinable implements the JoinOption interface for ExprOption
+func (opt ExprOption[T]) joinable() {}
+
 // QueryOption represents an option that modifies the entire query
 type QueryOption[T Table] func(*State, *ast.Query)
 
@@ -76,29 +162,111 @@ func (opt QueryOption[T]) Apply(s *State, q *ast.Query) {
 	opt(s, q)
 }
 
+// joinable implements the JoinOption interface for QueryOption
+func (opt QueryOption[T]) joinable() {}
+
+// QueryClause represents a LIMIT, GROUP BY or HAVING clause of a query
+// A flat join shares the clauses of its parent query, so QueryClause is not a JoinOption
+type QueryClause[T Table] func(*State, *ast.Query)
+
+// Apply implements the Option interface for QueryClause
+func (opt QueryClause[T]) Apply(s *State, q *ast.Query) {
+	opt(s, q)
+}
+
+// ColumnRef is implemented by every column of table T regardless of its value type
+type ColumnRef[T Table] interface {
+	ColumnName() string
+	table() T
+}
+
+// BaseColumn is the core of columns whose values Spanner cannot compare, such as ARRAY and JSON
+// It can be selected, assigned, counted and checked for NULL
+type BaseColumn[T Table, V any] struct {
+	Name string
+}
+
+// ColumnName returns the database column name
+func (c BaseColumn[T, V]) ColumnName() string {
+	return c.Name
+}
+
+// table ties the column to its table type
+func (c BaseColumn[T, V]) table() T {
+	var t T
+	return t
+}
+
+// BuildExpr builds the column reference qualified by the current table
+func (c BaseColumn[T, V]) BuildExpr(s *State) ast.Expr {
+	return &ast.Path{
+		Idents: []*ast.Ident{
+			{Name: s.CurrentAlias()},
+			{Name: c.Name},
+		},
+	}
+}
+
+// SelectItem builds the column as a SELECT list item
+func (c BaseColumn[T, V]) SelectItem(s *State) ast.SelectItem {
+	return &ast.ExprSelectItem{Expr: c.BuildExpr(s)}
+}
+
+// isNullExpr creates an IS NULL or IS NOT NULL expression
+func (c BaseColumn[T, V]) isNullExpr(not bool) ExprOption[T] {
+	return func(s *State, expr *ast.Expr) {
+		*expr = &ast.IsNullExpr{
+			Not:  not,
+			Left: c.BuildExpr(s),
+		}
+	}
+}
+
+// IsNull creates an IS NULL condition
+func (c BaseColumn[T, V]) IsNull() ExprOption[T] {
+	return c.isNullExpr(false)
+}
+
+// IsNotNull creates an IS NOT NULL condition
+func (c BaseColumn[T, V]) IsNotNull() ExprOption[T] {
+	return c.isNullExpr(true)
+}
+
 // Column represents a table column that can be used in various SQL contexts
+// It is the comparable core embedded by every column kind except ARRAY and JSON, see kinds.go
 type Column[T Table, V any] struct {
 	Name string
 }
 
+// base returns the column without its comparisons
+func (c Column[T, V]) base() BaseColumn[T, V] {
+	return BaseColumn[T, V]{Name: c.Name}
+}
+
+// ColumnName returns the database column name
+func (c Column[T, V]) ColumnName() string {
+	return c.Name
+}
+
+// table ties the column to its table type
+func (c Column[T, V]) table() T {
+	var t T
+	return t
+}
+
+// BuildExpr builds the column reference qualified by the current table
+func (c Column[T, V]) BuildExpr(s *State) ast.Expr {
+	return c.base().BuildExpr(s)
+}
+
+// SelectItem builds the column as a SELECT list item
+func (c Column[T, V]) SelectItem(s *State) ast.SelectItem {
+	return &ast.ExprSelectItem{Expr: c.BuildExpr(s)}
+}
+
 // Op creates a condition using the specified operator and value
 func (c Column[T, V]) Op(op ast.BinaryOp, value V) ExprOption[T] {
-	return func(s *State, expr *ast.Expr) {
-		i := len(s.Params)
-		s.Params = append(s.Params, value)
-		*expr = &ast.BinaryExpr{
-			Left: &ast.Path{
-				Idents: []*ast.Ident{
-					{Name: s.CurrentAlias()},
-					{Name: c.Name},
-				},
-			},
-			Op: op,
-			Right: &ast.Param{
-				Name: fmt.Sprintf("p%d", i),
-			},
-		}
-	}
+	return binaryParam[T](c.BuildExpr, op, value)
 }
 
 // Eq creates an equality condition (=)
@@ -131,18 +299,6 @@ func (c Column[T, V]) Ge(value V) ExprOption[T] {
 	return c.Op(ast.OpGreaterEqual, value)
 }
 
-// Like creates a LIKE condition (string columns only)
-func (c Column[T, string]) Like(value string) ExprOption[T] {
-	return c.Op(ast.OpLike, value)
-}
-
-// NotLike creates a NOT LIKE condition (string columns only)
-func (c Column[T, string]) NotLike(value string) ExprOption[T] {
-	return c.Op(ast.OpNotLike, value)
-}
-
-// Numeric column specific methods
-
 // Between creates a BETWEEN condition (comparable types only)
 func (c Column[T, V]) Between(min, max V) ExprOption[T] {
 	return func(s *State, expr *ast.Expr) {
@@ -152,13 +308,8 @@ func (c Column[T, V]) Between(min, max V) ExprOption[T] {
 		s.Params = append(s.Params, max)
 
 		*expr = &ast.BetweenExpr{
-			Not: false,
-			Left: &ast.Path{
-				Idents: []*ast.Ident{
-					{Name: s.CurrentAlias()},
-					{Name: c.Name},
-				},
-			},
+			Not:        false,
+			Left:       c.BuildExpr(s),
 			RightStart: &ast.Param{Name: fmt.Sprintf("p%d", minIdx)},
 			RightEnd:   &ast.Param{Name: fmt.Sprintf("p%d", maxIdx)},
 		}
@@ -176,13 +327,8 @@ func (c Column[T, V]) In(values ...V) ExprOption[T] {
 		}
 
 		*expr = &ast.InExpr{
-			Not: false,
-			Left: &ast.Path{
-				Idents: []*ast.Ident{
-					{Name: s.CurrentAlias()},
-					{Name: c.Name},
-				},
-			},
+			Not:  false,
+			Left: c.BuildExpr(s),
 			Right: &ast.ValuesInCondition{
 				Exprs: exprs,
 			},
@@ -190,27 +336,12 @@ func (c Column[T, V]) In(values ...V) ExprOption[T] {
 	}
 }
 
-// isNullExpr creates an IS NULL or IS NOT NULL expression
-func (c Column[T, V]) isNullExpr(not bool) ExprOption[T] {
-	return func(s *State, expr *ast.Expr) {
-		*expr = &ast.IsNullExpr{
-			Not: not,
-			Left: &ast.Path{
-				Idents: []*ast.Ident{
-					{Name: s.CurrentAlias()},
-					{Name: c.Name},
-				},
-			},
-		}
-	}
-}
-
 // IsNull creates an IS NULL condition
 func (c Column[T, V]) IsNull() ExprOption[T] {
-	return c.isNullExpr(false)
+	return c.base().IsNull()
 }
 
 // IsNotNull creates an IS NOT NULL condition
 func (c Column[T, V]) IsNotNull() ExprOption[T] {
-	return c.isNullExpr(true)
+	return c.base().IsNotNull()
 }
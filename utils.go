@@ -31,3 +31,56 @@ func toSnakeCase(s string) string {
 	}
 	return result.String()
 }
+
+// commonInitialisms are name parts written in upper case in Go identifiers
+var commonInitialisms = map[string]bool{
+	"API":  true,
+	"HTTP": true,
+	"ID":   true,
+	"IP":   true,
+	"JSON": true,
+	"SQL":  true,
+	"URL":  true,
+	"UUID": true,
+}
+
+// toCamelCase converts a snake_case string to CamelCase
+// Common initialisms are kept upper case, so "user_id" -> "UserID" round-trips with toSnakeCase
+func toCamelCase(s string) string {
+	var result strings.Builder
+	for _, part := range strings.Split(s, "_") {
+		if part == "" {
+			continue
+		}
+		if upper := strings.ToUpper(part); commonInitialisms[upper] {
+			result.WriteString(upper)
+			continue
+		}
+		result.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return result.String()
+}
+
+// pluralize returns the English plural of a CamelCase name (e.g., "Post" -> "Posts", "Category" -> "Categories")
+// Names that already end with "s" are returned as is
+func pluralize(s string) string {
+	switch {
+	case s == "" || strings.HasSuffix(s, "s"):
+		return s
+	case len(s) > 1 && strings.HasSuffix(s, "y") && !strings.ContainsAny(s[len(s)-2:len(s)-1], "aeiou"):
+		return s[:len(s)-1] + "ies"
+	case strings.HasSuffix(s, "x"), strings.HasSuffix(s, "ch"), strings.HasSuffix(s, "sh"):
+		return s + "es"
+	}
+	return s + "s"
+}
+
+// comment formats text as a Go line comment, one "// " line per line of text
+func comment(text string) string {
+	var sb strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		sb.WriteString(strings.TrimRight("// "+line, " "))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,343 @@
+package plate
+
+import (
+	"fmt"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// Diagnostic is a problem found while validating a schema
+type Diagnostic struct {
+	Pos     string // Source position of the declaration ("file:line:col"), empty when unknown
+	Subject string // Model, field or relation the problem is about (e.g., "Post.Author")
+	Message string
+}
+
+// String formats the diagnostic as "pos: subject: message"
+func (d Diagnostic) String() string {
+	if d.Pos == "" {
+		return fmt.Sprintf("%s: %s", d.Subject, d.Message)
+	}
+	return fmt.Sprintf("%s: %s: %s", d.Pos, d.Subject, d.Message)
+}
+
+// ValidationError reports every problem found in a schema
+type ValidationError struct {
+	Diagnostics []Diagnostic
+}
+
+// Error lists the diagnostics, one per line
+func (e *ValidationError) Error() string {
+	lines := make([]string, 0, len(e.Diagnostics)+1)
+	if len(e.Diagnostics) > 1 {
+		lines = append(lines, fmt.Sprintf("%d problems", len(e.Diagnostics)))
+	}
+	for _, d := range e.Diagnostics {
+		lines = append(lines, d.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// reservedPackageNames are imported by generated query builders or used by the tables package
+var reservedPackageNames = map[string]bool{
+	"ast":     true,
+	"query":   true,
+	"tables":  true,
+	"types":   true,
+	"time":    true,
+	"civil":   true,
+	"big":     true,
+	"spanner": true,
+}
+
+// builtinFunctions are declared by every generated query builder package
+var builtinFunctions = []string{
+	"Select", "Statement", "Insert", "Values", "OrUpdate", "OrIgnore", "Update", "Delete",
+	"Returning", "Limit", "Columns", "AddColumns", "OrderBy", "GroupBy", "Having", "CountAll", "Param",
+	"And", "Or", "Not", "AllRows", "Outer",
+}
+
+// validator collects diagnostics for a schema
+type validator struct {
+	g           *Generator
+	tableMap    map[string]TableSchema
+	diagnostics []Diagnostic
+}
+
+// addf records a diagnostic
+func (v *validator) addf(pos, subject, format string, args ...any) {
+	v.diagnostics = append(v.diagnostics, Diagnostic{Pos: pos, Subject: subject, Message: fmt.Sprintf(format, args...)})
+}
+
+// validateConfig validates the schema and returns a *ValidationError listing every problem
+// It checks table names, package names, relation targets and keys, and the names of
+// generated functions so that problems surface here instead of as compile errors in generated code
+func (g *Generator) validateConfig() error {
+	v := &validator{g: g, tableMap: g.buildTableMap()}
+
+	v.validateTables()
+	for _, tc := range g.schema.Tables {
+		for _, rel := range tc.Relations {
+			v.validateRelation(tc.Schema, rel)
+		}
+	}
+	for _, jc := range g.schema.Junctions {
+		name := g.getTypeName(jc.Schema)
+		if len(jc.Relations) != 2 {
+			v.addf(jc.Schema.Pos, name, "junction table must have exactly 2 relations, got %d", len(jc.Relations))
+		}
+		for _, rel := range jc.Relations {
+			v.validateRelation(jc.Schema, rel)
+		}
+	}
+	v.validateFunctionNames()
+
+	if len(v.diagnostics) == 0 {
+		return nil
+	}
+	return &ValidationError{Diagnostics: v.diagnostics}
+}
+
+// validateTables checks that models, table names and package names are unique and valid
+func (v *validator) validateTables() {
+	types := make(map[string]bool)
+	tableNames := make(map[string]string)
+	packages := make(map[string]string)
+
+	check := func(ts TableSchema, generated bool) {
+		name := v.g.getTypeName(ts)
+		if name == "" {
+			v.addf(ts.Pos, ts.TableName, "table has no model or type name")
+			return
+		}
+		if types[name] {
+			v.addf(ts.Pos, name, "duplicate table: %s", name)
+		}
+		types[name] = true
+
+		lower := strings.ToLower(ts.TableName)
+		if other, ok := tableNames[lower]; ok && lower != "" {
+			v.addf(ts.Pos, name, "table name %s is also used by %s", ts.TableName, other)
+		}
+		tableNames[lower] = name
+
+		if !generated {
+			return
+		}
+		pkg := v.g.toPackageName(name)
+		switch {
+		case !token.IsIdentifier(pkg):
+			v.addf(ts.Pos, name, "package name %q is not a valid Go identifier", pkg)
+		case reservedPackageNames[pkg]:
+			v.addf(ts.Pos, name, "package name %q collides with a package used by generated code", pkg)
+		}
+		if other, ok := packages[pkg]; ok {
+			v.addf(ts.Pos, name, "package name %q is also used by %s", pkg, other)
+		}
+		packages[pkg] = name
+	}
+
+	for _, tc := range v.g.schema.Tables {
+		check(tc.Schema, true)
+	}
+	for _, jc := range v.g.schema.Junctions {
+		check(jc.Schema, false)
+	}
+}
+
+// validateRelation checks that a relation targets a table and that its keys are fields of matching types
+func (v *validator) validateRelation(ts TableSchema, rel Relation) {
+	typeName := v.g.getTypeName(ts)
+	subject := typeName + "." + rel.Name
+	pos := rel.Pos
+	if pos == "" {
+		pos = ts.Pos
+	}
+
+	for _, name := range []string{rel.Name, rel.ReverseName} {
+		if name != "" && !(token.IsIdentifier(name) && token.IsExported(name)) {
+			v.addf(pos, subject, "relation name %q is not an exported Go identifier", name)
+		}
+	}
+	if rel.Name == "" {
+		v.addf(pos, typeName, "relation from %s has no name", rel.From)
+	}
+
+	from := v.field(ts, rel.From)
+	if from == nil {
+		v.addf(pos, subject, "%s has no field %s%s", typeName, rel.From, v.suggest(ts, rel.From))
+	}
+
+	target, ok := v.tableMap[rel.Target]
+	if !ok || !v.isTable(rel.Target) {
+		v.addf(pos, subject, "target %s is not a table of the schema%s", rel.Target, suggestName(rel.Target, v.tableNames()))
+		return
+	}
+	to := v.field(target, rel.To)
+	if to == nil {
+		v.addf(pos, subject, "%s has no field %s%s", rel.Target, rel.To, v.suggest(target, rel.To))
+		return
+	}
+
+	if from != nil && keyType(*from) != keyType(*to) {
+		v.addf(pos, subject, "key types differ: %s.%s is %s, %s.%s is %s",
+			typeName, rel.From, keyType(*from), rel.Target, rel.To, keyType(*to))
+	}
+}
+
+// validateFunctionNames checks that the functions generated for columns and relations of each
+// query builder package do not collide with each other or with the built-in functions
+func (v *validator) validateFunctionNames() {
+	// declared maps each table's generated function names to what declares them
+	declared := make(map[string]map[string]string)
+	for _, tc := range v.g.schema.Tables {
+		name := v.g.getTypeName(tc.Schema)
+		names := make(map[string]string)
+		for _, fn := range builtinFunctions {
+			names[fn] = "the built-in " + fn
+		}
+		for _, col := range v.g.getColumns(tc.Schema) {
+			pos := col.Pos
+			if pos == "" {
+				pos = tc.Schema.Pos
+			}
+			if prev, ok := names[col.Name]; ok {
+				v.addf(pos, name+"."+col.Name, "column accessor %s collides with %s", col.Name, prev)
+				continue
+			}
+			names[col.Name] = "column accessor " + col.Name
+		}
+		declared[name] = names
+	}
+
+	declare := func(typeName, relName, pos, what string) {
+		names, ok := declared[typeName]
+		if !ok || relName == "" {
+			return
+		}
+		for _, prefix := range []string{"With", "Where", "Join", "LeftJoin"} {
+			fn := prefix + relName
+			if prev, ok := names[fn]; ok {
+				v.addf(pos, typeName+"."+relName, "%s of %s collides with %s", fn, what, prev)
+				return
+			}
+		}
+		for _, prefix := range []string{"With", "Where", "Join", "LeftJoin"} {
+			names[prefix+relName] = what
+		}
+	}
+
+	for _, tc := range v.g.schema.Tables {
+		typeName := v.g.getTypeName(tc.Schema)
+		for _, rel := range tc.Relations {
+			pos := rel.Pos
+			if pos == "" {
+				pos = tc.Schema.Pos
+			}
+			declare(typeName, rel.Name, pos, "relation "+typeName+"."+rel.Name)
+			declare(rel.Target, rel.ReverseName, pos, "reverse relation "+rel.ReverseName+" of "+typeName+"."+rel.Name)
+		}
+	}
+	for _, jc := range v.g.schema.Junctions {
+		typeName := v.g.getTypeName(jc.Schema)
+		for _, rel := range jc.Relations {
+			pos := rel.Pos
+			if pos == "" {
+				pos = jc.Schema.Pos
+			}
+			declare(rel.Target, rel.ReverseName, pos, "reverse relation "+rel.ReverseName+" of "+typeName+"."+rel.Name)
+		}
+	}
+}
+
+// field returns the column of a table with the given field name, or nil
+func (v *validator) field(ts TableSchema, name string) *columnInfo {
+	for _, col := range v.g.getColumns(ts) {
+		if col.Name == name {
+			return &col
+		}
+	}
+	return nil
+}
+
+// isTable reports whether the type is a table with a query builder, rather than a junction table
+func (v *validator) isTable(typeName string) bool {
+	for _, tc := range v.g.schema.Tables {
+		if v.g.getTypeName(tc.Schema) == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+// tableNames returns the type names of the tables with query builders
+func (v *validator) tableNames() []string {
+	var names []string
+	for _, tc := range v.g.schema.Tables {
+		names = append(names, v.g.getTypeName(tc.Schema))
+	}
+	return names
+}
+
+// suggest returns a hint naming the field of the table closest to name
+func (v *validator) suggest(ts TableSchema, name string) string {
+	var fields []string
+	for _, col := range v.g.getColumns(ts) {
+		fields = append(fields, col.Name)
+	}
+	return suggestName(name, fields)
+}
+
+// suggestName returns ", did you mean X?" for the candidate that differs from name by case or
+// at most two edits, or an empty string
+func suggestName(name string, candidates []string) string {
+	sort.Strings(candidates)
+	best, bestDistance := "", 3
+	for _, c := range candidates {
+		d := editDistance(strings.ToLower(name), strings.ToLower(c))
+		if d < bestDistance {
+			best, bestDistance = c, d
+		}
+	}
+	if best == "" {
+		return ""
+	}
+	return fmt.Sprintf(", did you mean %s?", best)
+}
+
+// editDistance returns the Levenshtein distance between two strings
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur := make([]int, len(b)+1)
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev = cur
+	}
+	return prev[len(b)]
+}
+
+// keyType returns the type a relation key is compared by: the Spanner type without its length,
+// or the Go type without pointer and Null* wrappers when there is no Spanner type
+func keyType(col columnInfo) string {
+	if col.SpannerType != "" {
+		return strings.ToUpper(lengthPattern.ReplaceAllString(strings.ReplaceAll(col.SpannerType, " ", ""), ""))
+	}
+	goType := strings.TrimPrefix(col.GoType, "*")
+	for base, nullable := range nullableGoTypes {
+		if nullable == goType {
+			return base
+		}
+	}
+	return goType
+}
@@ -0,0 +1,115 @@
+package plate_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/rail44/plate"
+)
+
+type vUser struct {
+	ID    string `spanner:"id" spannerType:"STRING(36)"`
+	Limit int64  `spanner:"limit"`
+}
+
+type vPost struct {
+	ID       string `spanner:"id" spannerType:"STRING(36)"`
+	UserID   string `spanner:"user_id" spannerType:"STRING(36)"`
+	EditorID int64  `spanner:"editor_id"`
+}
+
+type Query struct {
+	ID string `spanner:"id"`
+}
+
+type Type struct {
+	ID string `spanner:"id"`
+}
+
+type vPostTag struct {
+	PostID string `spanner:"post_id"`
+}
+
+func TestValidateSchema(t *testing.T) {
+	schema := plate.Schema{
+		Tables: []plate.TableConfig{
+			{Schema: plate.TableSchema{TableName: "user", Model: vUser{}}},
+			{
+				Schema: plate.TableSchema{TableName: "post", Model: vPost{}},
+				Relations: []plate.Relation{
+					{Name: "Author", Target: "vUsr", From: "UserID", To: "ID"},
+					{Name: "Owner", Target: "vUser", From: "UserId", To: "ID", ReverseName: "Posts"},
+					{Name: "Editor", Target: "vUser", From: "EditorID", To: "ID", ReverseName: "Posts"},
+					{Name: "Reviewer", Target: "vUser", From: "UserID", To: "Key"},
+					{Name: "second", Target: "vUser", From: "UserID", To: "ID"},
+				},
+			},
+			{Schema: plate.TableSchema{TableName: "query", Model: Query{}}},
+			{Schema: plate.TableSchema{TableName: "type", Model: Type{}}},
+		},
+		Junctions: []plate.JunctionConfig{
+			{
+				Schema: plate.TableSchema{TableName: "post_tag", Model: vPostTag{}},
+				Relations: []plate.Relation{
+					{Name: "Post", Target: "vPost", From: "PostID", To: "ID"},
+				},
+			},
+		},
+	}
+
+	_, err := plate.NewGenerator().GenerateFiles(schema, "./generated")
+	var verr *plate.ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected a *ValidationError, got %v", err)
+	}
+
+	var got []string
+	for _, d := range verr.Diagnostics {
+		got = append(got, d.String())
+	}
+	want := []string{
+		`Query: package name "query" collides with a package used by generated code`,
+		`Type: package name "type" is not a valid Go identifier`,
+		`vPost.Author: target vUsr is not a table of the schema, did you mean vUser?`,
+		`vPost.Owner: vPost has no field UserId, did you mean UserID?`,
+		`vPost.Editor: key types differ: vPost.EditorID is INT64, vUser.ID is STRING`,
+		`vPost.Reviewer: vUser has no field Key`,
+		`vPost.second: relation name "second" is not an exported Go identifier`,
+		`vPostTag: junction table must have exactly 2 relations, got 1`,
+		`vUser.Limit: column accessor Limit collides with the built-in Limit`,
+		`vUser.Posts: WithPosts of reverse relation Posts of vPost.Editor collides with reverse relation Posts of vPost.Owner`,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Diagnostics mismatch\ngot:\n%s\nwant:\n%s", strings.Join(got, "\n"), strings.Join(want, "\n"))
+	}
+	if !strings.HasPrefix(err.Error(), "invalid configuration: 10 problems\n") {
+		t.Errorf("Error should list every problem, got:\n%v", err)
+	}
+}
+
+func TestValidateSchemaPositions(t *testing.T) {
+	models, err := plate.LoadModels(".", "./testdata/models")
+	if err != nil {
+		t.Fatalf("Failed to load models: %v", err)
+	}
+	article := models["Article"]
+	article.TableName = "article"
+
+	// Article declares its Author relation with a tag, pointing at a table left out of the schema
+	schema := plate.Schema{
+		Tables: []plate.TableConfig{
+			{Schema: article},
+		},
+	}
+	_, err = plate.NewGenerator().GenerateFiles(schema, "./generated")
+	var verr *plate.ValidationError
+	if !errors.As(err, &verr) || len(verr.Diagnostics) != 1 {
+		t.Fatalf("Expected one diagnostic, got %v", err)
+	}
+	d := verr.Diagnostics[0]
+	if !strings.HasSuffix(d.Pos, "models.go:34:2") || d.Subject != "Article.Author" {
+		t.Errorf("Diagnostic should point at the AuthorID field, got %s", d)
+	}
+}